@@ -0,0 +1,221 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// CountService determines the number of documents matching a query
+// without fetching any hits.
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/search-count.html.
+type CountService struct {
+	pretty     *bool    // pretty format the returned JSON response
+	human      *bool    // return human readable values for statistics
+	errorTrace *bool    // include the stack trace of returned errors
+	filterPath []string // list of filters used to reduce the response
+
+	index             []string
+	query             Query
+	routing           string
+	preference        string
+	terminateAfter    *int
+	minScore          interface{}
+	ignoreUnavailable *bool
+	allowNoIndices    *bool
+	expandWildcards   string
+	bodyJson          interface{}
+	bodyString        string
+}
+
+// NewCountService creates a new CountService.
+func NewCountService(indices ...string) *CountService {
+	return &CountService{
+		index: indices,
+	}
+}
+
+// Pretty tells Elasticsearch whether to return a formatted JSON response.
+func (s *CountService) Pretty(pretty bool) *CountService {
+	s.pretty = &pretty
+	return s
+}
+
+// Human specifies whether human readable values should be returned in
+// the JSON response, e.g. "7.5mb".
+func (s *CountService) Human(human bool) *CountService {
+	s.human = &human
+	return s
+}
+
+// ErrorTrace specifies whether to include the stack trace of returned errors.
+func (s *CountService) ErrorTrace(errorTrace bool) *CountService {
+	s.errorTrace = &errorTrace
+	return s
+}
+
+// FilterPath specifies a list of filters used to reduce the response.
+func (s *CountService) FilterPath(filterPath ...string) *CountService {
+	s.filterPath = filterPath
+	return s
+}
+
+// Index sets the names of the indices to restrict the results.
+func (s *CountService) Index(index ...string) *CountService {
+	s.index = append(s.index, index...)
+	return s
+}
+
+// Query specifies the query to pass. It is sent as the request body.
+func (s *CountService) Query(query Query) *CountService {
+	s.query = query
+	return s
+}
+
+// Routing specifies the routing value.
+func (s *CountService) Routing(routing string) *CountService {
+	s.routing = routing
+	return s
+}
+
+// Preference specifies the node or shard the operation should be
+// performed on (default: random).
+func (s *CountService) Preference(preference string) *CountService {
+	s.preference = preference
+	return s
+}
+
+// TerminateAfter indicates the maximum count for each shard, upon reaching
+// which the query execution will terminate early.
+func (s *CountService) TerminateAfter(terminateAfter int) *CountService {
+	s.terminateAfter = &terminateAfter
+	return s
+}
+
+// MinScore indicates to include only documents with a specific `_score`
+// value in the result.
+func (s *CountService) MinScore(minScore interface{}) *CountService {
+	s.minScore = minScore
+	return s
+}
+
+// IgnoreUnavailable indicates whether specified concrete indices should be
+// ignored when unavailable (missing or closed).
+func (s *CountService) IgnoreUnavailable(ignoreUnavailable bool) *CountService {
+	s.ignoreUnavailable = &ignoreUnavailable
+	return s
+}
+
+// AllowNoIndices indicates whether to ignore if a wildcard indices
+// expression resolves into no concrete indices.
+func (s *CountService) AllowNoIndices(allowNoIndices bool) *CountService {
+	s.allowNoIndices = &allowNoIndices
+	return s
+}
+
+// ExpandWildcards indicates whether to expand wildcard expression to
+// concrete indices that are open, closed or both.
+func (s *CountService) ExpandWildcards(expandWildcards string) *CountService {
+	s.expandWildcards = expandWildcards
+	return s
+}
+
+// BodyJson specifies the query to restrict the results specified with the
+// Query DSL (optional). The interface{} will be serialized to a JSON document,
+// so use a map[string]interface{}.
+func (s *CountService) BodyJson(body interface{}) *CountService {
+	s.bodyJson = body
+	return s
+}
+
+// BodyString specifies a query to restrict the results specified with
+// the Query DSL (optional).
+func (s *CountService) BodyString(body string) *CountService {
+	s.bodyString = body
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *CountService) buildURL() (string, url.Values, error) {
+	path := "/_count"
+	if len(s.index) > 0 {
+		escaped := make([]string, len(s.index))
+		for i, index := range s.index {
+			escaped[i] = url.PathEscape(index)
+		}
+		path = "/" + strings.Join(escaped, ",") + path
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if v := s.pretty; v != nil {
+		params.Set("pretty", fmt.Sprint(*v))
+	}
+	if v := s.human; v != nil {
+		params.Set("human", fmt.Sprint(*v))
+	}
+	if v := s.errorTrace; v != nil {
+		params.Set("error_trace", fmt.Sprint(*v))
+	}
+	if len(s.filterPath) > 0 {
+		params.Set("filter_path", strings.Join(s.filterPath, ","))
+	}
+	if s.routing != "" {
+		params.Set("routing", s.routing)
+	}
+	if s.preference != "" {
+		params.Set("preference", s.preference)
+	}
+	if s.terminateAfter != nil {
+		params.Set("terminate_after", fmt.Sprint(*s.terminateAfter))
+	}
+	if s.minScore != nil {
+		params.Set("min_score", fmt.Sprint(s.minScore))
+	}
+	if s.ignoreUnavailable != nil {
+		params.Set("ignore_unavailable", fmt.Sprint(*s.ignoreUnavailable))
+	}
+	if s.allowNoIndices != nil {
+		params.Set("allow_no_indices", fmt.Sprint(*s.allowNoIndices))
+	}
+	if s.expandWildcards != "" {
+		params.Set("expand_wildcards", s.expandWildcards)
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *CountService) Validate() error {
+	return nil
+}
+
+// body returns the request body, if any. A query set via Query takes
+// precedence over BodyJson and BodyString. Without any of them, all
+// documents are counted and the request is sent without a body.
+func (s *CountService) body() (interface{}, error) {
+	if s.query != nil {
+		src, err := s.query.Source()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"query": src}, nil
+	}
+	if s.bodyJson != nil {
+		return s.bodyJson, nil
+	}
+	if s.bodyString != "" {
+		return s.bodyString, nil
+	}
+	return nil, nil
+}
+
+// CountResponse is the response of using the Count API.
+type CountResponse struct {
+	Count           int64       `json:"count"`
+	TerminatedEarly bool        `json:"terminated_early,omitempty"`
+	Shards          *ShardsInfo `json:"_shards,omitempty"`
+}
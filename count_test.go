@@ -0,0 +1,76 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCountBuildURL(t *testing.T) {
+	tests := []struct {
+		Service *CountService
+		Path    string
+		Params  string
+	}{
+		{NewCountService(), "/_count", ""},
+		{NewCountService("twitter"), "/twitter/_count", ""},
+		{NewCountService("twitter", "facebook").Routing("user1").Preference("_local"), "/twitter,facebook/_count", "preference=_local&routing=user1"},
+		{NewCountService("twitter").TerminateAfter(100).MinScore(0.5), "/twitter/_count", "min_score=0.5&terminate_after=100"},
+	}
+	for i, tt := range tests {
+		path, params, err := tt.Service.buildURL()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if want, have := tt.Path, path; want != have {
+			t.Errorf("#%d: want path %q, have %q", i, want, have)
+		}
+		if want, have := tt.Params, params.Encode(); want != have {
+			t.Errorf("#%d: want params %q, have %q", i, want, have)
+		}
+	}
+}
+
+func TestCountBody(t *testing.T) {
+	src, err := NewCountService("twitter").body()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if src != nil {
+		t.Fatalf("expected no body; got: %v", src)
+	}
+
+	src, err = NewCountService("twitter").Query(NewTermQuery("user", "olivere")).body()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"query":{"term":{"user":"olivere"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestCountResponse(t *testing.T) {
+	body := `{"count":42,"_shards":{"total":5,"successful":5,"skipped":0,"failed":0}}`
+	var res CountResponse
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := int64(42), res.Count; want != have {
+		t.Errorf("want count %d, have %d", want, have)
+	}
+	if res.Shards == nil {
+		t.Fatal("want shards info")
+	}
+	if want, have := 5, res.Shards.Successful; want != have {
+		t.Errorf("want %d successful shards, have %d", want, have)
+	}
+}
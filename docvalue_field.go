@@ -4,6 +4,12 @@
 
 package elastic
 
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
 // DocvalueField represents a docvalue field, its name and
 // its format (optional).
 type DocvalueField struct {
@@ -22,6 +28,28 @@ func (d DocvalueField) Source() (interface{}, error) {
 	}, nil
 }
 
+// Validate performs a light sanity check of the DocvalueField. It requires
+// a field name and, if a format is given, checks that every alternative of
+// a date format like "yyyy-MM-dd||epoch_millis" is non-empty and has
+// balanced quotes. It does not check if Elasticsearch knows the format.
+func (d DocvalueField) Validate() error {
+	if strings.TrimSpace(d.Field) == "" {
+		return errors.New("elastic: docvalue field name is missing")
+	}
+	if d.Format == "" {
+		return nil
+	}
+	for _, format := range strings.Split(d.Format, "||") {
+		if strings.TrimSpace(format) == "" {
+			return fmt.Errorf("elastic: docvalue field %q has an empty format in %q", d.Field, d.Format)
+		}
+		if strings.Count(format, "'")%2 != 0 {
+			return fmt.Errorf("elastic: docvalue field %q has unbalanced quotes in format %q", d.Field, format)
+		}
+	}
+	return nil
+}
+
 // DocvalueFields is a slice of DocvalueField instances.
 type DocvalueFields []DocvalueField
 
@@ -40,3 +68,13 @@ func (d DocvalueFields) Source() (interface{}, error) {
 	}
 	return v, nil
 }
+
+// Validate checks all DocvalueField instances.
+func (d DocvalueFields) Validate() error {
+	for _, f := range d {
+		if err := f.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -56,3 +56,28 @@ func TestDocvalueFields(t *testing.T) {
 		t.Fatalf("Source(%#v): want %v, have %v", doc, want, have)
 	}
 }
+
+func TestDocvalueFieldValidate(t *testing.T) {
+	tests := []struct {
+		Doc     DocvalueField
+		IsValid bool
+	}{
+		{DocvalueField{}, false},
+		{DocvalueField{Field: " "}, false},
+		{DocvalueField{Field: "name"}, true},
+		{DocvalueField{Field: "created", Format: "epoch_millis"}, true},
+		{DocvalueField{Field: "created", Format: "yyyy-MM-dd'T'HH:mm:ss||epoch_millis"}, true},
+		{DocvalueField{Field: "created", Format: "yyyy-MM-dd||"}, false},
+		{DocvalueField{Field: "created", Format: "yyyy-MM-dd'T HH:mm"}, false},
+		{DocvalueField{Format: "epoch_millis"}, false},
+	}
+	for i, tt := range tests {
+		err := tt.Doc.Validate()
+		if tt.IsValid && err != nil {
+			t.Errorf("#%d: Validate(%#v): expected no error, got %v", i, tt.Doc, err)
+		}
+		if !tt.IsValid && err == nil {
+			t.Errorf("#%d: Validate(%#v): expected error", i, tt.Doc)
+		}
+	}
+}
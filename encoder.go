@@ -0,0 +1,24 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+)
+
+// Encoder is used to encode request bodies into JSON.
+// See Request.SetBodyWithEncoder for details.
+type Encoder interface {
+	Encode(v interface{}) ([]byte, error)
+}
+
+// DefaultEncoder uses json.Marshal from the Go standard library
+// to encode JSON.
+type DefaultEncoder struct{}
+
+// Encode encodes v with json.Marshal.
+func (e *DefaultEncoder) Encode(v interface{}) ([]byte, error) {
+	return json.Marshal(v)
+}
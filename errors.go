@@ -7,14 +7,42 @@ package elastic
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 var nilByte = []byte("null")
 
+// ErrTimeout is raised when a request timed out.
+var ErrTimeout = errors.New("timeout")
+
+// ErrUnsupportedProduct is returned by the opt-in product check (see
+// SearchService.ProductCheck) when a successful response lacks the
+// "X-Elastic-Product: Elasticsearch" header, i.e. the server is most
+// probably not Elasticsearch.
+var ErrUnsupportedProduct = errors.New("elastic: the server is not Elasticsearch (missing or unexpected X-Elastic-Product header)")
+
+// productHeader is the header Elasticsearch 7.14+ sends with every response.
+const productHeader = "X-Elastic-Product"
+
+// checkProductHeader returns ErrUnsupportedProduct if res is a successful
+// response without the "X-Elastic-Product: Elasticsearch" header.
+// Responses with other status codes are not checked, as e.g. a proxy in
+// front of Elasticsearch may answer 401 on its own.
+func checkProductHeader(res *http.Response) error {
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return nil
+	}
+	if res.Header.Get(productHeader) != "Elasticsearch" {
+		return ErrUnsupportedProduct
+	}
+	return nil
+}
+
 // checkResponse will return an error if the request/response indicates
 // an error returned from Elasticsearch.
 //
@@ -101,6 +129,37 @@ type ScriptErrorPosition struct {
 	End    int `json:"end"`
 }
 
+// ShardFailure is a typed representation of an entry in
+// ErrorDetails.FailedShards.
+type ShardFailure struct {
+	Shard  int           `json:"shard"`
+	Index  string        `json:"index,omitempty"`
+	Node   string        `json:"node,omitempty"`
+	Reason *ErrorDetails `json:"reason,omitempty"`
+}
+
+// FailedShardsParsed returns the failed shards of the error, e.g. of a
+// search_phase_execution_exception, as a slice of ShardFailure.
+// Entries that cannot be converted are skipped.
+func (e *ErrorDetails) FailedShardsParsed() []ShardFailure {
+	if e == nil || len(e.FailedShards) == 0 {
+		return nil
+	}
+	failures := make([]ShardFailure, 0, len(e.FailedShards))
+	for _, shard := range e.FailedShards {
+		data, err := json.Marshal(shard)
+		if err != nil {
+			continue
+		}
+		var f ShardFailure
+		if err := json.Unmarshal(data, &f); err != nil {
+			continue
+		}
+		failures = append(failures, f)
+	}
+	return failures
+}
+
 // Error returns a string representation of the error.
 func (e *Error) Error() string {
 	if e.Details != nil && e.Details.Reason != "" {
@@ -139,6 +198,45 @@ func IsContextErr(err error) bool {
 	return false
 }
 
+// IsTimeout returns true if err signals a client-side timeout, i.e. a
+// context deadline that was exceeded, a url.Error that timed out, or
+// ErrTimeout, also when wrapped. It also returns true for HTTP status 408 (Request Timeout)
+// returned from Elasticsearch.
+//
+// Notice that a search that times out on the server side is not an error;
+// use SearchResult.DidTimeout for that.
+func IsTimeout(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrTimeout) {
+		return true
+	}
+	var ue *url.Error
+	if errors.As(err, &ue) {
+		return ue.Timeout() || IsTimeout(ue.Err)
+	}
+	if e, ok := err.(*Error); ok && e != nil {
+		return e.Status == http.StatusRequestTimeout
+	}
+	return false
+}
+
+// IsConflict returns true if err signals a version conflict, i.e. HTTP
+// status 409 (Conflict) or an error of type
+// "version_conflict_engine_exception". This happens e.g. when indexing
+// with op_type=create and the document already exists.
+func IsConflict(err error) bool {
+	e, ok := err.(*Error)
+	if !ok || e == nil {
+		return false
+	}
+	if e.Status == http.StatusConflict {
+		return true
+	}
+	return e.Details != nil && e.Details.Type == "version_conflict_engine_exception"
+}
+
 // -- General errors --
 
 // ShardsInfo represents information from a shard.
@@ -162,6 +260,26 @@ type ShardOperationFailedException struct {
 	Primary bool `json:"primary,omitempty"`
 }
 
+// ReasonType returns the type of the failure, e.g. "query_shard_exception",
+// or an empty string if it is not available.
+func (e *ShardOperationFailedException) ReasonType() string {
+	if e == nil {
+		return ""
+	}
+	v, _ := e.Reason["type"].(string)
+	return v
+}
+
+// ReasonText returns the human-readable reason of the failure, or an
+// empty string if it is not available.
+func (e *ShardOperationFailedException) ReasonText() string {
+	if e == nil {
+		return ""
+	}
+	v, _ := e.Reason["reason"].(string)
+	return v
+}
+
 type BroadcastResponse struct {
 	Shards     *ShardsInfo                      `json:"_shards,omitempty"`
 	Total      int                              `json:"total"`
@@ -175,3 +293,85 @@ type FailedNodeException struct {
 	*ErrorDetails
 	NodeId string `json:"node_id"`
 }
+
+// IsFailedNode returns true and the id of the node if err is an error
+// of type "failed_node_exception", e.g. returned by cluster-level APIs
+// when a node could not be reached. The root causes are inspected as
+// well. The node id is empty if it cannot be determined.
+func IsFailedNode(err error) (string, bool) {
+	e, ok := err.(*Error)
+	if !ok || e == nil || e.Details == nil {
+		return "", false
+	}
+	if e.Details.Type == "failed_node_exception" {
+		return failedNodeId(e.Details.Reason, e.Details.CausedBy), true
+	}
+	for _, rc := range e.Details.RootCause {
+		if rc != nil && rc.Type == "failed_node_exception" {
+			return failedNodeId(rc.Reason, rc.CausedBy), true
+		}
+	}
+	if e.Details.CausedBy != nil && e.Details.CausedBy["type"] == "failed_node_exception" {
+		if id, ok := e.Details.CausedBy["node_id"].(string); ok {
+			return id, true
+		}
+		reason, _ := e.Details.CausedBy["reason"].(string)
+		return failedNodeId(reason, nil), true
+	}
+	return "", false
+}
+
+// failedNodeId extracts the node id from the reason of a
+// FailedNodeException, which reads "Failed node [<node id>]".
+func failedNodeId(reason string, causedBy map[string]interface{}) string {
+	if id, ok := causedBy["node_id"].(string); ok && id != "" {
+		return id
+	}
+	const prefix = "Failed node ["
+	if i := strings.Index(reason, prefix); i >= 0 {
+		rest := reason[i+len(prefix):]
+		if j := strings.Index(rest, "]"); j >= 0 {
+			return rest[:j]
+		}
+	}
+	return ""
+}
+
+// IsExpensiveQueryDisallowed returns true if err was raised because a
+// query like script, regexp, wildcard or prefix cannot be executed while
+// the cluster setting search.allow_expensive_queries is false. The root
+// causes and failed shards of the error are inspected as well.
+func IsExpensiveQueryDisallowed(err error) bool {
+	e, ok := err.(*Error)
+	if !ok || e == nil || e.Details == nil {
+		return false
+	}
+	if isExpensiveQueryReason(e.Details.Reason) {
+		return true
+	}
+	for _, rc := range e.Details.RootCause {
+		if rc != nil && isExpensiveQueryReason(rc.Reason) {
+			return true
+		}
+	}
+	if reason, ok := e.Details.CausedBy["reason"].(string); ok && isExpensiveQueryReason(reason) {
+		return true
+	}
+	for _, shard := range e.Details.FailedShards {
+		r, ok := shard["reason"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if reason, ok := r["reason"].(string); ok && isExpensiveQueryReason(reason) {
+			return true
+		}
+	}
+	return false
+}
+
+// isExpensiveQueryReason checks if the reason of an error refers to the
+// search.allow_expensive_queries setting, e.g.
+// "[regexp] queries cannot be executed when 'search.allow_expensive_queries' is set to false.".
+func isExpensiveQueryReason(reason string) bool {
+	return strings.Contains(reason, "search.allow_expensive_queries")
+}
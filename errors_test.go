@@ -6,8 +6,12 @@ package elastic
 
 import (
 	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"testing"
 )
@@ -155,3 +159,353 @@ func TestResponseErrorWithIgnore(t *testing.T) {
 		t.Fatalf("expected no error; got: %v", err)
 	}
 }
+
+func TestShardOperationFailedExceptionReason(t *testing.T) {
+	raw := `{
+	"took": 12,
+	"timed_out": false,
+	"_shards": {
+		"total": 2,
+		"successful": 1,
+		"skipped": 0,
+		"failed": 1,
+		"failures": [
+			{
+				"shard": 0,
+				"index": "elastic-test",
+				"node": "hYx2GZ5xS9GWeJBMD2x0mQ",
+				"reason": {
+					"type": "query_shard_exception",
+					"reason": "failed to create query: For input string: \"abc\"",
+					"index_uuid": "8SZmvpvhRl-oKVyeCrnsfQ",
+					"index": "elastic-test",
+					"caused_by": {
+						"type": "number_format_exception",
+						"reason": "For input string: \"abc\""
+					}
+				}
+			}
+		]
+	},
+	"hits": { "total": { "value": 0, "relation": "eq" }, "hits": [] }
+}`
+	var res SearchResult
+	if err := json.Unmarshal([]byte(raw), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Shards == nil || len(res.Shards.Failures) != 1 {
+		t.Fatalf("expected 1 shard failure; got: %+v", res.Shards)
+	}
+	f := res.Shards.Failures[0]
+	if want, have := "query_shard_exception", f.ReasonType(); want != have {
+		t.Fatalf("want %q, have %q", want, have)
+	}
+	if want, have := `failed to create query: For input string: "abc"`, f.ReasonText(); want != have {
+		t.Fatalf("want %q, have %q", want, have)
+	}
+
+	// Missing or malformed reasons
+	if want, have := "", (&ShardOperationFailedException{}).ReasonType(); want != have {
+		t.Fatalf("want %q, have %q", want, have)
+	}
+	if want, have := "", (&ShardOperationFailedException{Reason: map[string]interface{}{"reason": 42}}).ReasonText(); want != have {
+		t.Fatalf("want %q, have %q", want, have)
+	}
+	var nilErr *ShardOperationFailedException
+	if want, have := "", nilErr.ReasonText(); want != have {
+		t.Fatalf("want %q, have %q", want, have)
+	}
+}
+
+func TestIsFailedNode(t *testing.T) {
+	raw := `{
+	"error": {
+		"root_cause": [
+			{
+				"type": "failed_node_exception",
+				"reason": "Failed node [hYx2GZ5xS9GWeJBMD2x0mQ]"
+			}
+		],
+		"type": "failed_node_exception",
+		"reason": "Failed node [hYx2GZ5xS9GWeJBMD2x0mQ]",
+		"caused_by": {
+			"type": "node_not_connected_exception",
+			"reason": "[node-1][127.0.0.1:9300] Node not connected"
+		}
+	},
+	"status": 500
+}`
+	e := new(Error)
+	if err := json.Unmarshal([]byte(raw), e); err != nil {
+		t.Fatal(err)
+	}
+	nodeId, ok := IsFailedNode(e)
+	if !ok {
+		t.Fatal("expected failed node error")
+	}
+	if want, have := "hYx2GZ5xS9GWeJBMD2x0mQ", nodeId; want != have {
+		t.Fatalf("want %q, have %q", want, have)
+	}
+
+	// Failed node as root cause only
+	e = &Error{
+		Status: 500,
+		Details: &ErrorDetails{
+			Type:      "exception",
+			RootCause: []*ErrorDetails{{Type: "failed_node_exception", Reason: "Failed node [node-2]"}},
+		},
+	}
+	nodeId, ok = IsFailedNode(e)
+	if !ok {
+		t.Fatal("expected failed node error")
+	}
+	if want, have := "node-2", nodeId; want != have {
+		t.Fatalf("want %q, have %q", want, have)
+	}
+
+	// Other errors
+	if _, ok := IsFailedNode(nil); ok {
+		t.Fatal("expected nil not to be a failed node error")
+	}
+	if _, ok := IsFailedNode(&Error{Status: 404, Details: &ErrorDetails{Type: "index_not_found_exception"}}); ok {
+		t.Fatal("expected index_not_found_exception not to be a failed node error")
+	}
+	if _, ok := IsFailedNode(fmt.Errorf("failed node")); ok {
+		t.Fatal("expected plain error not to be a failed node error")
+	}
+}
+
+type timeoutError struct{}
+
+func (timeoutError) Error() string   { return "i/o timeout" }
+func (timeoutError) Timeout() bool   { return true }
+func (timeoutError) Temporary() bool { return true }
+
+func TestIsTimeout(t *testing.T) {
+	tests := []struct {
+		Error error
+		Want  bool
+	}{
+		{nil, false},
+		{errors.New("boom"), false},
+		{context.Canceled, false},
+		{context.DeadlineExceeded, true},
+		{fmt.Errorf("search failed: %w", context.DeadlineExceeded), true},
+		{ErrTimeout, true},
+		{&url.Error{Op: "Get", URL: "http://127.0.0.1:9200", Err: timeoutError{}}, true},
+		{&url.Error{Op: "Get", URL: "http://127.0.0.1:9200", Err: context.DeadlineExceeded}, true},
+		{&url.Error{Op: "Get", URL: "http://127.0.0.1:9200", Err: errors.New("connection refused")}, false},
+		{fmt.Errorf("search failed: %w", &url.Error{Op: "Get", URL: "http://127.0.0.1:9200", Err: timeoutError{}}), true},
+		{fmt.Errorf("search failed: %w", &url.Error{Op: "Get", URL: "http://127.0.0.1:9200", Err: errors.New("connection refused")}), false},
+		{&Error{Status: http.StatusRequestTimeout}, true},
+		{&Error{Status: http.StatusNotFound}, false},
+	}
+	for i, tt := range tests {
+		if want, have := tt.Want, IsTimeout(tt.Error); want != have {
+			t.Errorf("#%d: IsTimeout(%v): want %v, have %v", i, tt.Error, want, have)
+		}
+	}
+}
+
+func TestIsConflict(t *testing.T) {
+	raw := "HTTP/1.1 409 Conflict\r\n" +
+		"\r\n" +
+		`{"error":{"root_cause":[{"type":"version_conflict_engine_exception","reason":"[1]: version conflict, document already exists (current version [1])","index":"tweets"}],"type":"version_conflict_engine_exception","reason":"[1]: version conflict, document already exists (current version [1])","index":"tweets"},"status":409}` + "\r\n"
+	r := bufio.NewReader(strings.NewReader(raw))
+
+	req, err := http.NewRequest("PUT", "/tweets/_create/1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.ReadResponse(r, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = checkResponse(req, resp)
+	if !IsConflict(err) {
+		t.Fatalf("expected conflict; got: %v", err)
+	}
+
+	tests := []struct {
+		Error error
+		Want  bool
+	}{
+		{nil, false},
+		{errors.New("boom"), false},
+		{&Error{Status: http.StatusConflict}, true},
+		{&Error{Details: &ErrorDetails{Type: "version_conflict_engine_exception"}}, true},
+		{&Error{Status: http.StatusNotFound}, false},
+	}
+	for i, tt := range tests {
+		if want, have := tt.Want, IsConflict(tt.Error); want != have {
+			t.Errorf("#%d: IsConflict(%v): want %v, have %v", i, tt.Error, want, have)
+		}
+	}
+}
+
+func TestCheckProductHeader(t *testing.T) {
+	tests := []struct {
+		StatusCode int
+		Header     http.Header
+		Want       error
+	}{
+		{http.StatusOK, http.Header{"X-Elastic-Product": []string{"Elasticsearch"}}, nil},
+		{http.StatusOK, http.Header{}, ErrUnsupportedProduct},
+		{http.StatusOK, http.Header{"X-Elastic-Product": []string{"OpenSearch"}}, ErrUnsupportedProduct},
+		{http.StatusUnauthorized, http.Header{}, nil},
+	}
+	for i, tt := range tests {
+		res := &http.Response{StatusCode: tt.StatusCode, Header: tt.Header}
+		if want, have := tt.Want, checkProductHeader(res); want != have {
+			t.Errorf("#%d: want %v, have %v", i, want, have)
+		}
+	}
+}
+
+func TestIsExpensiveQueryDisallowed(t *testing.T) {
+	raw := `{
+	"error": {
+		"root_cause": [
+			{
+				"type": "exception",
+				"reason": "[regexp] queries cannot be executed when 'search.allow_expensive_queries' is set to false."
+			}
+		],
+		"type": "search_phase_execution_exception",
+		"reason": "all shards failed",
+		"phase": "query",
+		"grouped": true,
+		"failed_shards": [
+			{
+				"shard": 0,
+				"index": "twitter",
+				"node": "hYx2GZ5xS9GWeJBMD2x0mQ",
+				"reason": {
+					"type": "exception",
+					"reason": "[regexp] queries cannot be executed when 'search.allow_expensive_queries' is set to false."
+				}
+			}
+		]
+	},
+	"status": 400
+}`
+	e := new(Error)
+	if err := json.Unmarshal([]byte(raw), e); err != nil {
+		t.Fatal(err)
+	}
+	if !IsExpensiveQueryDisallowed(e) {
+		t.Fatal("expected expensive query error")
+	}
+
+	// Failed shards only
+	e.Details.RootCause = nil
+	if !IsExpensiveQueryDisallowed(e) {
+		t.Fatal("expected expensive query error from failed shards")
+	}
+
+	// Other errors
+	if IsExpensiveQueryDisallowed(nil) {
+		t.Fatal("expected nil not to be an expensive query error")
+	}
+	if IsExpensiveQueryDisallowed(&Error{Status: 400, Details: &ErrorDetails{Type: "parsing_exception", Reason: "unknown query [foo]"}}) {
+		t.Fatal("expected parsing exception not to be an expensive query error")
+	}
+	if IsExpensiveQueryDisallowed(fmt.Errorf("boom")) {
+		t.Fatal("expected generic error not to be an expensive query error")
+	}
+}
+
+func TestErrorDetailsFailedShardsParsed(t *testing.T) {
+	raw := `{
+	"error": {
+		"root_cause": [
+			{
+				"type": "query_shard_exception",
+				"reason": "failed to create query: For input string: \"abc\"",
+				"index": "twitter"
+			}
+		],
+		"type": "search_phase_execution_exception",
+		"reason": "all shards failed",
+		"phase": "query",
+		"grouped": true,
+		"failed_shards": [
+			{
+				"shard": 0,
+				"index": "twitter",
+				"node": "hYx2GZ5xS9GWeJBMD2x0mQ",
+				"reason": {
+					"type": "query_shard_exception",
+					"reason": "failed to create query: For input string: \"abc\"",
+					"index": "twitter",
+					"caused_by": {
+						"type": "number_format_exception",
+						"reason": "For input string: \"abc\""
+					}
+				}
+			},
+			{
+				"shard": 3,
+				"index": "twitter-2",
+				"node": "U1SxdNsHQOa5vmVxoUNnDw",
+				"reason": {
+					"type": "illegal_argument_exception",
+					"reason": "field [user] is not searchable"
+				}
+			}
+		]
+	},
+	"status": 400
+}`
+	e := new(Error)
+	if err := json.Unmarshal([]byte(raw), e); err != nil {
+		t.Fatal(err)
+	}
+	failures := e.Details.FailedShardsParsed()
+	if want, have := 2, len(failures); want != have {
+		t.Fatalf("want %d failed shards, have %d", want, have)
+	}
+
+	f := failures[0]
+	if want, have := 0, f.Shard; want != have {
+		t.Fatalf("want %d, have %d", want, have)
+	}
+	if want, have := "twitter", f.Index; want != have {
+		t.Fatalf("want %q, have %q", want, have)
+	}
+	if want, have := "hYx2GZ5xS9GWeJBMD2x0mQ", f.Node; want != have {
+		t.Fatalf("want %q, have %q", want, have)
+	}
+	if f.Reason == nil {
+		t.Fatal("expected reason")
+	}
+	if want, have := "query_shard_exception", f.Reason.Type; want != have {
+		t.Fatalf("want %q, have %q", want, have)
+	}
+	if want, have := "number_format_exception", f.Reason.CausedBy["type"]; want != have {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+
+	f = failures[1]
+	if want, have := 3, f.Shard; want != have {
+		t.Fatalf("want %d, have %d", want, have)
+	}
+	if want, have := "twitter-2", f.Index; want != have {
+		t.Fatalf("want %q, have %q", want, have)
+	}
+	if f.Reason == nil {
+		t.Fatal("expected reason")
+	}
+	if want, have := "field [user] is not searchable", f.Reason.Reason; want != have {
+		t.Fatalf("want %q, have %q", want, have)
+	}
+
+	// No failed shards
+	if have := (&ErrorDetails{}).FailedShardsParsed(); have != nil {
+		t.Fatalf("expected nil; got: %+v", have)
+	}
+	var nilDetails *ErrorDetails
+	if have := nilDetails.FailedShardsParsed(); have != nil {
+		t.Fatalf("expected nil; got: %+v", have)
+	}
+}
@@ -0,0 +1,190 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ExplainService computes a score explanation for a query and
+// a specific document.
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/search-explain.html.
+type ExplainService struct {
+	pretty     *bool    // pretty format the returned JSON response
+	human      *bool    // return human readable values for statistics
+	errorTrace *bool    // include the stack trace of returned errors
+	filterPath []string // list of filters used to reduce the response
+
+	id         string
+	index      string
+	query      Query
+	routing    string
+	preference string
+	analyzer   string
+	lenient    *bool
+	bodyJson   interface{}
+	bodyString string
+}
+
+// NewExplainService creates a new ExplainService.
+func NewExplainService() *ExplainService {
+	return &ExplainService{}
+}
+
+// Pretty tells Elasticsearch whether to return a formatted JSON response.
+func (s *ExplainService) Pretty(pretty bool) *ExplainService {
+	s.pretty = &pretty
+	return s
+}
+
+// Human specifies whether human readable values should be returned in
+// the JSON response, e.g. "7.5mb".
+func (s *ExplainService) Human(human bool) *ExplainService {
+	s.human = &human
+	return s
+}
+
+// ErrorTrace specifies whether to include the stack trace of returned errors.
+func (s *ExplainService) ErrorTrace(errorTrace bool) *ExplainService {
+	s.errorTrace = &errorTrace
+	return s
+}
+
+// FilterPath specifies a list of filters used to reduce the response.
+func (s *ExplainService) FilterPath(filterPath ...string) *ExplainService {
+	s.filterPath = filterPath
+	return s
+}
+
+// Id is the document ID.
+func (s *ExplainService) Id(id string) *ExplainService {
+	s.id = id
+	return s
+}
+
+// Index is the name of the index.
+func (s *ExplainService) Index(index string) *ExplainService {
+	s.index = index
+	return s
+}
+
+// Query sets the query to explain the score for.
+func (s *ExplainService) Query(query Query) *ExplainService {
+	s.query = query
+	return s
+}
+
+// Routing sets a specific routing value.
+func (s *ExplainService) Routing(routing string) *ExplainService {
+	s.routing = routing
+	return s
+}
+
+// Preference specifies the node or shard the operation should be
+// performed on (default: random).
+func (s *ExplainService) Preference(preference string) *ExplainService {
+	s.preference = preference
+	return s
+}
+
+// Analyzer is the analyzer for the query string query.
+func (s *ExplainService) Analyzer(analyzer string) *ExplainService {
+	s.analyzer = analyzer
+	return s
+}
+
+// Lenient specifies whether format-based query failures
+// (such as providing text to a numeric field) should be ignored.
+func (s *ExplainService) Lenient(lenient bool) *ExplainService {
+	s.lenient = &lenient
+	return s
+}
+
+// BodyJson sets the query definition using the Query DSL.
+func (s *ExplainService) BodyJson(body interface{}) *ExplainService {
+	s.bodyJson = body
+	return s
+}
+
+// BodyString sets the query definition using the Query DSL as a string.
+func (s *ExplainService) BodyString(body string) *ExplainService {
+	s.bodyString = body
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *ExplainService) buildURL() (string, url.Values, error) {
+	path := fmt.Sprintf("/%s/_explain/%s", url.PathEscape(s.index), url.PathEscape(s.id))
+
+	// Add query string parameters
+	params := url.Values{}
+	if v := s.pretty; v != nil {
+		params.Set("pretty", fmt.Sprint(*v))
+	}
+	if v := s.human; v != nil {
+		params.Set("human", fmt.Sprint(*v))
+	}
+	if v := s.errorTrace; v != nil {
+		params.Set("error_trace", fmt.Sprint(*v))
+	}
+	if len(s.filterPath) > 0 {
+		params.Set("filter_path", strings.Join(s.filterPath, ","))
+	}
+	if s.routing != "" {
+		params.Set("routing", s.routing)
+	}
+	if s.preference != "" {
+		params.Set("preference", s.preference)
+	}
+	if s.analyzer != "" {
+		params.Set("analyzer", s.analyzer)
+	}
+	if s.lenient != nil {
+		params.Set("lenient", fmt.Sprint(*s.lenient))
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *ExplainService) Validate() error {
+	var invalid []string
+	if s.index == "" {
+		invalid = append(invalid, "Index")
+	}
+	if s.id == "" {
+		invalid = append(invalid, "Id")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// body returns the request body. A query set via Query takes precedence
+// over BodyJson and BodyString.
+func (s *ExplainService) body() (interface{}, error) {
+	if s.query != nil {
+		src, err := s.query.Source()
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"query": src}, nil
+	}
+	if s.bodyJson != nil {
+		return s.bodyJson, nil
+	}
+	return s.bodyString, nil
+}
+
+// ExplainResponse describes what is returned by an explain request.
+type ExplainResponse struct {
+	Index       string            `json:"_index"`
+	Type        string            `json:"_type"`
+	Id          string            `json:"_id"`
+	Matched     bool              `json:"matched"`
+	Explanation SearchExplanation `json:"explanation"`
+}
@@ -0,0 +1,100 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestExplainBuildURL(t *testing.T) {
+	tests := []struct {
+		Service *ExplainService
+		Path    string
+		Params  string
+	}{
+		{NewExplainService().Index("twitter").Id("1"), "/twitter/_explain/1", ""},
+		{NewExplainService().Index("twitter").Id("a/b").Routing("user1"), "/twitter/_explain/a%2Fb", "routing=user1"},
+		{NewExplainService().Index("twitter").Id("1").Preference("_local").Lenient(true), "/twitter/_explain/1", "lenient=true&preference=_local"},
+	}
+	for i, tt := range tests {
+		path, params, err := tt.Service.buildURL()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if want, have := tt.Path, path; want != have {
+			t.Errorf("#%d: want path %q, have %q", i, want, have)
+		}
+		if want, have := tt.Params, params.Encode(); want != have {
+			t.Errorf("#%d: want params %q, have %q", i, want, have)
+		}
+	}
+}
+
+func TestExplainValidate(t *testing.T) {
+	err := NewExplainService().Validate()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if want, have := "missing required fields: [Index Id]", err.Error(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestExplainBody(t *testing.T) {
+	s := NewExplainService().Index("twitter").Id("1").Query(NewTermQuery("user", "olivere"))
+	src, err := s.body()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"query":{"term":{"user":"olivere"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestExplainResponse(t *testing.T) {
+	body := `{
+		"_index": "twitter",
+		"_type": "_doc",
+		"_id": "1",
+		"matched": true,
+		"explanation": {
+			"value": 1.6943598,
+			"description": "weight(message:elasticsearch in 0) [PerFieldSimilarity], result of:",
+			"details": [
+				{
+					"value": 1.6943598,
+					"description": "score(freq=1.0), computed as boost * idf * tf from:",
+					"details": []
+				}
+			]
+		}
+	}`
+	var res ExplainResponse
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "1", res.Id; want != have {
+		t.Errorf("want id %q, have %q", want, have)
+	}
+	if !res.Matched {
+		t.Error("want matched")
+	}
+	if want, have := 1.6943598, res.Explanation.Value; want != have {
+		t.Errorf("want value %v, have %v", want, have)
+	}
+	if want, have := 1, len(res.Explanation.Details); want != have {
+		t.Fatalf("want %d details, have %d", want, have)
+	}
+	if want, have := "score(freq=1.0), computed as boost * idf * tf from:", res.Explanation.Details[0].Description; want != have {
+		t.Errorf("want description %q, have %q", want, have)
+	}
+}
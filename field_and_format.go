@@ -0,0 +1,45 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// FieldAndFormat represents a field to retrieve via the fields API,
+// its name and its format (optional).
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/search-fields.html#search-fields-param
+// for details.
+type FieldAndFormat struct {
+	Field  string
+	Format string
+}
+
+// Source serializes the FieldAndFormat into JSON.
+func (f FieldAndFormat) Source() (interface{}, error) {
+	if f.Format == "" {
+		return f.Field, nil
+	}
+	return map[string]interface{}{
+		"field":  f.Field,
+		"format": f.Format,
+	}, nil
+}
+
+// FieldAndFormats is a slice of FieldAndFormat instances.
+type FieldAndFormats []FieldAndFormat
+
+// Source serializes the FieldAndFormats into JSON.
+func (f FieldAndFormats) Source() (interface{}, error) {
+	if f == nil {
+		return nil, nil
+	}
+	v := make([]interface{}, 0)
+	for _, field := range f {
+		src, err := field.Source()
+		if err != nil {
+			return nil, err
+		}
+		v = append(v, src)
+	}
+	return v, nil
+}
@@ -0,0 +1,40 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFieldAndFormat(t *testing.T) {
+	tests := []struct {
+		Field FieldAndFormat
+		Want  interface{}
+	}{
+		{
+			Field: FieldAndFormat{},
+			Want:  "",
+		},
+		{
+			Field: FieldAndFormat{Field: "user.id"},
+			Want:  "user.id",
+		},
+		{
+			Field: FieldAndFormat{Field: "@timestamp", Format: "epoch_millis"},
+			Want:  map[string]interface{}{"field": "@timestamp", "format": "epoch_millis"},
+		},
+	}
+	for _, tt := range tests {
+		have, err := tt.Field.Source()
+		if err != nil {
+			t.Fatalf("Source(%#v): err=%v", tt.Field, err)
+		}
+		if want := tt.Want; !cmp.Equal(want, have) {
+			t.Fatalf("Source(%#v): want %v, have %v", tt.Field, want, have)
+		}
+	}
+}
@@ -22,6 +22,11 @@ type MultiSearchService struct {
 	preFilterShardSize    *int
 }
 
+// NewMultiSearchService creates a new MultiSearchService.
+func NewMultiSearchService() *MultiSearchService {
+	return &MultiSearchService{}
+}
+
 // Pretty tells Elasticsearch whether to return a formatted JSON response.
 func (s *MultiSearchService) Pretty(pretty bool) *MultiSearchService {
 	s.pretty = &pretty
@@ -81,3 +86,29 @@ func (s *MultiSearchService) PreFilterShardSize(size int) *MultiSearchService {
 	s.preFilterShardSize = &size
 	return s
 }
+
+// Body returns the NDJSON body of the request, consisting of a header
+// and a search body line for each of the search requests.
+// Indices set on the service are used for requests that do not specify
+// indices on their own.
+func (s *MultiSearchService) Body() (string, error) {
+	lines := make([]interface{}, 0, 2*len(s.requests))
+	for _, sr := range s.requests {
+		// Set default indices if not specified in the request
+		if !sr.HasIndices() && len(s.indices) > 0 {
+			sr = sr.Index(s.indices...)
+		}
+		body, err := sr.Body()
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, sr.header(), body)
+	}
+	return ndjsonBody(lines...)
+}
+
+// MultiSearchResult is the outcome of running a multi-search operation.
+type MultiSearchResult struct {
+	TookInMillis int64           `json:"took,omitempty"` // search time in milliseconds
+	Responses    []*SearchResult `json:"responses,omitempty"`
+}
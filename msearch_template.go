@@ -0,0 +1,254 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// MultiSearchTemplateService executes one or more search templates in
+// one roundtrip. The responses are returned in a MultiSearchResult.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/multi-search-template.html
+// for details.
+type MultiSearchTemplateService struct {
+	pretty     *bool    // pretty format the returned JSON response
+	human      *bool    // return human readable values for statistics
+	errorTrace *bool    // include the stack trace of returned errors
+	filterPath []string // list of filters used to reduce the response
+
+	requests              []*SearchTemplateRequest
+	indices               []string
+	maxConcurrentSearches *int
+}
+
+// NewMultiSearchTemplateService creates a new MultiSearchTemplateService.
+func NewMultiSearchTemplateService() *MultiSearchTemplateService {
+	return &MultiSearchTemplateService{}
+}
+
+// Pretty tells Elasticsearch whether to return a formatted JSON response.
+func (s *MultiSearchTemplateService) Pretty(pretty bool) *MultiSearchTemplateService {
+	s.pretty = &pretty
+	return s
+}
+
+// Human specifies whether human readable values should be returned in
+// the JSON response, e.g. "7.5mb".
+func (s *MultiSearchTemplateService) Human(human bool) *MultiSearchTemplateService {
+	s.human = &human
+	return s
+}
+
+// ErrorTrace specifies whether to include the stack trace of returned errors.
+func (s *MultiSearchTemplateService) ErrorTrace(errorTrace bool) *MultiSearchTemplateService {
+	s.errorTrace = &errorTrace
+	return s
+}
+
+// FilterPath specifies a list of filters used to reduce the response.
+func (s *MultiSearchTemplateService) FilterPath(filterPath ...string) *MultiSearchTemplateService {
+	s.filterPath = filterPath
+	return s
+}
+
+// Add adds one or more search template requests.
+func (s *MultiSearchTemplateService) Add(requests ...*SearchTemplateRequest) *MultiSearchTemplateService {
+	s.requests = append(s.requests, requests...)
+	return s
+}
+
+// Index sets the default indices for requests that do not specify any.
+func (s *MultiSearchTemplateService) Index(indices ...string) *MultiSearchTemplateService {
+	s.indices = append(s.indices, indices...)
+	return s
+}
+
+// MaxConcurrentSearches sets the maximum number of searches executed
+// concurrently.
+func (s *MultiSearchTemplateService) MaxConcurrentSearches(max int) *MultiSearchTemplateService {
+	s.maxConcurrentSearches = &max
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *MultiSearchTemplateService) buildURL() (string, url.Values, error) {
+	path := "/_msearch/template"
+
+	// Add query string parameters
+	params := url.Values{}
+	if v := s.pretty; v != nil {
+		params.Set("pretty", fmt.Sprint(*v))
+	}
+	if v := s.human; v != nil {
+		params.Set("human", fmt.Sprint(*v))
+	}
+	if v := s.errorTrace; v != nil {
+		params.Set("error_trace", fmt.Sprint(*v))
+	}
+	if len(s.filterPath) > 0 {
+		params.Set("filter_path", strings.Join(s.filterPath, ","))
+	}
+	if v := s.maxConcurrentSearches; v != nil {
+		params.Set("max_concurrent_searches", fmt.Sprint(*v))
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *MultiSearchTemplateService) Validate() error {
+	if len(s.requests) == 0 {
+		return errors.New("elastic: multi search template needs at least one request")
+	}
+	for _, r := range s.requests {
+		if err := r.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Body returns the NDJSON body of the request, consisting of a header
+// and a template line for each of the requests.
+// Indices set on the service are used for requests that do not specify
+// indices on their own.
+func (s *MultiSearchTemplateService) Body() (string, error) {
+	lines := make([]interface{}, 0, 2*len(s.requests))
+	for _, r := range s.requests {
+		header := r.header()
+		if !r.HasIndices() && len(s.indices) > 0 {
+			header["index"] = strings.Join(s.indices, ",")
+		}
+		body, err := r.Body()
+		if err != nil {
+			return "", err
+		}
+		lines = append(lines, header, body)
+	}
+	return ndjsonBody(lines...)
+}
+
+// -- SearchTemplateRequest --
+
+// SearchTemplateRequest is a single request of a MultiSearchTemplateService.
+type SearchTemplateRequest struct {
+	indices    []string
+	searchType string
+	routing    string
+	preference string
+	id         string
+	source     interface{}
+	params     map[string]interface{}
+}
+
+// NewSearchTemplateRequest creates a new SearchTemplateRequest.
+func NewSearchTemplateRequest() *SearchTemplateRequest {
+	return &SearchTemplateRequest{}
+}
+
+// Index specifies the indices to use in the request.
+func (r *SearchTemplateRequest) Index(indices ...string) *SearchTemplateRequest {
+	r.indices = append(r.indices, indices...)
+	return r
+}
+
+// HasIndices returns true if there are indices used in the request.
+func (r *SearchTemplateRequest) HasIndices() bool {
+	return len(r.indices) > 0
+}
+
+// SearchType sets the search type, e.g. "dfs_query_then_fetch".
+func (r *SearchTemplateRequest) SearchType(searchType string) *SearchTemplateRequest {
+	r.searchType = searchType
+	return r
+}
+
+// Routing specifies the routing parameter. It is a comma-separated list.
+func (r *SearchTemplateRequest) Routing(routing string) *SearchTemplateRequest {
+	r.routing = routing
+	return r
+}
+
+// Preference to execute the search. Defaults to randomize across shards.
+func (r *SearchTemplateRequest) Preference(preference string) *SearchTemplateRequest {
+	r.preference = preference
+	return r
+}
+
+// Id is the ID of a stored search template.
+func (r *SearchTemplateRequest) Id(id string) *SearchTemplateRequest {
+	r.id = id
+	return r
+}
+
+// Source is an inline template, either as a string or as a
+// JSON-serializable value like a map.
+func (r *SearchTemplateRequest) Source(source interface{}) *SearchTemplateRequest {
+	r.source = source
+	return r
+}
+
+// Param sets a single template parameter.
+func (r *SearchTemplateRequest) Param(name string, value interface{}) *SearchTemplateRequest {
+	if r.params == nil {
+		r.params = make(map[string]interface{})
+	}
+	r.params[name] = value
+	return r
+}
+
+// Params sets the template parameters.
+func (r *SearchTemplateRequest) Params(params map[string]interface{}) *SearchTemplateRequest {
+	r.params = params
+	return r
+}
+
+// Validate checks if the request is valid.
+func (r *SearchTemplateRequest) Validate() error {
+	if r.id == "" && r.source == nil {
+		return errors.New("elastic: search template needs either Id or Source")
+	}
+	if r.id != "" && r.source != nil {
+		return errors.New("elastic: search template must not have both Id and Source")
+	}
+	return nil
+}
+
+// header is used e.g. by MultiSearchTemplate to get information about
+// the search header of one SearchTemplateRequest.
+func (r *SearchTemplateRequest) header() map[string]interface{} {
+	h := make(map[string]interface{})
+	if len(r.indices) > 0 {
+		h["index"] = strings.Join(r.indices, ",")
+	}
+	if r.searchType != "" {
+		h["search_type"] = r.searchType
+	}
+	if r.routing != "" {
+		h["routing"] = r.routing
+	}
+	if r.preference != "" {
+		h["preference"] = r.preference
+	}
+	return h
+}
+
+// Body returns the template line of the request.
+func (r *SearchTemplateRequest) Body() (interface{}, error) {
+	body := make(map[string]interface{})
+	if r.id != "" {
+		body["id"] = r.id
+	}
+	if r.source != nil {
+		body["source"] = r.source
+	}
+	if len(r.params) > 0 {
+		body["params"] = r.params
+	}
+	return body, nil
+}
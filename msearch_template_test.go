@@ -0,0 +1,75 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMultiSearchTemplateBody(t *testing.T) {
+	req1 := NewSearchTemplateRequest().Index("twitter", "facebook").
+		Id("my-template").
+		Param("query_string", "hello world")
+	req2 := NewSearchTemplateRequest().
+		Source(`{"query":{"match":{"{{field}}":"{{value}}"}}}`).
+		Params(map[string]interface{}{"field": "user", "value": "olivere"})
+
+	s := NewMultiSearchTemplateService().Index("logs").Add(req1, req2)
+	if err := s.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	body, err := s.Body()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"index":"twitter,facebook"}
+{"id":"my-template","params":{"query_string":"hello world"}}
+{"index":"logs"}
+{"params":{"field":"user","value":"olivere"},"source":"{\"query\":{\"match\":{\"{{field}}\":\"{{value}}\"}}}"}
+`
+	if body != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, body)
+	}
+}
+
+func TestMultiSearchTemplateBuildURL(t *testing.T) {
+	path, params, err := NewMultiSearchTemplateService().MaxConcurrentSearches(2).buildURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "/_msearch/template", path; want != have {
+		t.Errorf("want path %q, have %q", want, have)
+	}
+	if want, have := "max_concurrent_searches=2", params.Encode(); want != have {
+		t.Errorf("want params %q, have %q", want, have)
+	}
+}
+
+func TestMultiSearchTemplateValidate(t *testing.T) {
+	if err := NewMultiSearchTemplateService().Validate(); err == nil {
+		t.Error("expected error without requests")
+	}
+	if err := NewMultiSearchTemplateService().Add(NewSearchTemplateRequest()).Validate(); err == nil {
+		t.Error("expected error for request without Id and Source")
+	}
+}
+
+func TestMultiSearchTemplateResult(t *testing.T) {
+	body := `{"took":5,"responses":[{"took":2,"hits":{"total":{"value":1,"relation":"eq"},"hits":[{"_id":"1"}]},"status":200},{"error":{"type":"index_not_found_exception","reason":"no such index [logs]"},"status":404}]}`
+	var res MultiSearchResult
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 2, len(res.Responses); want != have {
+		t.Fatalf("want %d responses, have %d", want, have)
+	}
+	if want, have := int64(1), res.Responses[0].TotalHits(); want != have {
+		t.Errorf("want %d hits, have %d", want, have)
+	}
+	if err := res.Responses[1].Err(); err == nil {
+		t.Error("expected error in second response")
+	}
+}
@@ -4,6 +4,11 @@
 
 package elastic
 
+import (
+	"encoding/json"
+	"testing"
+)
+
 // import (
 // 	"context"
 // 	"encoding/json"
@@ -301,3 +306,86 @@ package elastic
 // 		}
 // 	}
 // }
+
+func TestMultiSearchBody(t *testing.T) {
+	sreq1 := NewSearchRequest().Index("test", "test2").
+		Source(NewSearchSource().Query(NewMatchAllQuery()).Size(10))
+	sreq2 := NewSearchRequest().
+		Source(NewSearchSource().Query(NewTermQuery("tags", "golang")))
+
+	body, err := NewMultiSearchService().Index("test").Add(sreq1, sreq2).Body()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"indices":["test","test2"]}
+{"query":{"match_all":{}},"size":10}
+{"index":"test"}
+{"query":{"term":{"tags":"golang"}}}
+`
+	if body != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, body)
+	}
+}
+
+func TestMultiSearchResultWithError(t *testing.T) {
+	body := `{
+		"took": 12,
+		"responses": [
+			{
+				"took": 5,
+				"timed_out": false,
+				"hits": {"total": {"value": 1, "relation": "eq"}, "hits": [{"_index": "tweets", "_id": "1", "_score": 1.0}]},
+				"status": 200
+			},
+			{
+				"error": {
+					"root_cause": [{"type": "index_not_found_exception", "reason": "no such index [missing]", "index": "missing"}],
+					"type": "index_not_found_exception",
+					"reason": "no such index [missing]",
+					"index": "missing"
+				},
+				"status": 404
+			}
+		]
+	}`
+	var res MultiSearchResult
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 2, len(res.Responses); want != have {
+		t.Fatalf("expected %d responses; got %d", want, have)
+	}
+
+	first := res.Responses[0]
+	if err := first.Err(); err != nil {
+		t.Fatalf("expected no error; got %v", err)
+	}
+	if want, have := 200, first.Status; want != have {
+		t.Errorf("expected status %d; got %d", want, have)
+	}
+	if want, have := int64(1), first.TotalHits(); want != have {
+		t.Errorf("expected %d hits; got %d", want, have)
+	}
+
+	failed := res.Responses[1]
+	if want, have := 404, failed.Status; want != have {
+		t.Errorf("expected status %d; got %d", want, have)
+	}
+	err := failed.Err()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	e, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("expected *Error; got %T", err)
+	}
+	if want, have := 404, e.Status; want != have {
+		t.Errorf("expected status %d; got %d", want, have)
+	}
+	if want, have := "index_not_found_exception", e.Details.Type; want != have {
+		t.Errorf("expected type %q; got %q", want, have)
+	}
+	if want, have := "no such index [missing]", ErrorReason(err); want != have {
+		t.Errorf("expected reason %q; got %q", want, have)
+	}
+}
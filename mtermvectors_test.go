@@ -4,6 +4,11 @@
 
 package elastic
 
+import (
+	"encoding/json"
+	"testing"
+)
+
 // import (
 // 	"context"
 // 	"testing"
@@ -131,3 +136,74 @@ package elastic
 // 		t.Fatalf("expected to have 2 docs; got %d", len(res.Docs))
 // 	}
 // }
+
+func TestMultiTermVectorsSource(t *testing.T) {
+	byId := NewMultiTermvectorItem().
+		Index("twitter").
+		Id("1").
+		Fields("message").
+		TermStatistics(true)
+	artificial := NewMultiTermvectorItem().
+		Index("twitter").
+		Doc(map[string]interface{}{"message": "hello world"}).
+		FieldStatistics(false)
+
+	s := NewMultiTermvectorService().Add(byId, artificial)
+	data, err := json.Marshal(s.Source())
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"docs":[{"_id":"1","_index":"twitter","fields":["message"],"term_statistics":"true"},{"_index":"twitter","doc":{"message":"hello world"},"field_statistics":"false"}]}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestMultiTermVectorsResponseDecode(t *testing.T) {
+	raw := `{
+	"docs": [
+		{
+			"_index": "twitter",
+			"_id": "1",
+			"_version": 1,
+			"found": true,
+			"took": 3,
+			"term_vectors": {
+				"message": {
+					"field_statistics": { "sum_doc_freq": 2, "doc_count": 1, "sum_ttf": 2 },
+					"terms": { "hello": { "term_freq": 1 }, "world": { "term_freq": 1 } }
+				}
+			}
+		},
+		{
+			"_index": "twitter",
+			"_version": 0,
+			"found": true,
+			"took": 1,
+			"term_vectors": {
+				"message": {
+					"field_statistics": { "sum_doc_freq": 2, "doc_count": 1, "sum_ttf": 2 },
+					"terms": { "hello": { "term_freq": 1 } }
+				}
+			}
+		}
+	]
+}`
+	var res MultiTermvectorResponse
+	if err := json.Unmarshal([]byte(raw), &res); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 2, len(res.Docs); want != have {
+		t.Fatalf("expected %d docs; got: %d", want, have)
+	}
+	if want, have := "1", res.Docs[0].Id; want != have {
+		t.Fatalf("expected id %q; got: %q", want, have)
+	}
+	if want, have := 2, len(res.Docs[0].TermVectors["message"].Terms); want != have {
+		t.Fatalf("expected %d terms; got: %d", want, have)
+	}
+	if want, have := "", res.Docs[1].Id; want != have {
+		t.Fatalf("expected id %q; got: %q", want, have)
+	}
+}
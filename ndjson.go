@@ -0,0 +1,58 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+// ndjsonBody builds a newline-delimited JSON body as required by
+// endpoints like Bulk or MultiSearch. Each line is serialized with
+// json.Marshal, except for strings and json.RawMessage values which are
+// assumed to be encoded already. Lines are joined by "\n" and the
+// body is terminated by a trailing newline, as Elasticsearch requires.
+func ndjsonBody(lines ...interface{}) (string, error) {
+	return ndjsonBodyWithEncoder(nil, lines...)
+}
+
+// ndjsonBodyWithEncoder is like ndjsonBody but serializes lines with enc.
+// If enc is nil, DefaultEncoder is used.
+func ndjsonBodyWithEncoder(enc Encoder, lines ...interface{}) (string, error) {
+	if enc == nil {
+		enc = &DefaultEncoder{}
+	}
+	var sb strings.Builder
+	for _, line := range lines {
+		var s string
+		switch v := line.(type) {
+		case string:
+			s = v
+		case json.RawMessage:
+			s = string(v)
+		case *json.RawMessage:
+			if v != nil {
+				s = string(*v)
+			}
+		default:
+			data, err := enc.Encode(v)
+			if err != nil {
+				return "", err
+			}
+			s = string(data)
+		}
+		s = strings.TrimSpace(s)
+		if s == "" {
+			return "", errors.New("elastic: NDJSON line must not be empty")
+		}
+		if strings.ContainsAny(s, "\r\n") {
+			return "", errors.New("elastic: NDJSON line must not contain newlines")
+		}
+		sb.WriteString(s)
+		sb.WriteByte('\n')
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,143 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// NdjsonRequestService sends a newline-delimited JSON body, made up of
+// pairs of action and document lines like in the Bulk API, to an
+// arbitrary endpoint, e.g. one exposed by a plugin.
+type NdjsonRequestService struct {
+	headers http.Header // custom request-level HTTP headers
+
+	method  string
+	path    string
+	lines   []interface{}
+	encoder Encoder
+}
+
+// NewNdjsonRequestService creates a new NdjsonRequestService.
+// The method defaults to POST.
+func NewNdjsonRequestService() *NdjsonRequestService {
+	return &NdjsonRequestService{
+		method: "POST",
+	}
+}
+
+// Header adds a header to the request.
+func (s *NdjsonRequestService) Header(name string, value string) *NdjsonRequestService {
+	if s.headers == nil {
+		s.headers = http.Header{}
+	}
+	s.headers.Add(name, value)
+	return s
+}
+
+// Headers specifies the headers of the request.
+func (s *NdjsonRequestService) Headers(headers http.Header) *NdjsonRequestService {
+	s.headers = headers
+	return s
+}
+
+// Method sets the HTTP method, e.g. "POST" or "PUT".
+func (s *NdjsonRequestService) Method(method string) *NdjsonRequestService {
+	s.method = method
+	return s
+}
+
+// Path sets the path of the endpoint, e.g. "/_my_plugin/_ingest".
+func (s *NdjsonRequestService) Path(path string) *NdjsonRequestService {
+	s.path = path
+	return s
+}
+
+// Encoder sets the encoder used to serialize the lines of the body.
+// If not set, DefaultEncoder is used.
+func (s *NdjsonRequestService) Encoder(enc Encoder) *NdjsonRequestService {
+	s.encoder = enc
+	return s
+}
+
+// Add appends an action line and a document line to the body. Both are
+// serialized via json.Marshal, except for strings and json.RawMessage
+// which are used as is. A nil doc adds the action line only, e.g. for
+// a delete action.
+func (s *NdjsonRequestService) Add(action, doc interface{}) *NdjsonRequestService {
+	s.lines = append(s.lines, action)
+	if doc != nil {
+		s.lines = append(s.lines, doc)
+	}
+	return s
+}
+
+// Body returns the NDJSON body of the request.
+func (s *NdjsonRequestService) Body() (string, error) {
+	return ndjsonBodyWithEncoder(s.encoder, s.lines...)
+}
+
+// Validate checks if the operation is valid.
+func (s *NdjsonRequestService) Validate() error {
+	var invalid []string
+	if s.method == "" {
+		invalid = append(invalid, "Method")
+	}
+	if s.path == "" {
+		invalid = append(invalid, "Path")
+	}
+	if len(s.lines) == 0 {
+		invalid = append(invalid, "Lines")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// buildRequest builds the HTTP request for the operation against baseURL,
+// with a Content-Type of application/x-ndjson.
+func (s *NdjsonRequestService) buildRequest(baseURL string) (*Request, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	body, err := s.Body()
+	if err != nil {
+		return nil, err
+	}
+	path := s.path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	req, err := NewRequest(s.method, strings.TrimSuffix(baseURL, "/")+path)
+	if err != nil {
+		return nil, err
+	}
+	for name, values := range s.headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	if err := req.SetBodyWithEncoder(body, false, s.encoder); err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-ndjson")
+	return req, nil
+}
+
+// decodeResponse decodes the HTTP response of the operation into target,
+// which must be a pointer, e.g. to a struct or a map.
+func (s *NdjsonRequestService) decodeResponse(req *http.Request, res *http.Response, target interface{}) error {
+	if err := checkResponse(req, res); err != nil {
+		return err
+	}
+	if res.Body == nil || target == nil {
+		return nil
+	}
+	return json.NewDecoder(res.Body).Decode(target)
+}
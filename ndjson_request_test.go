@@ -0,0 +1,96 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestNdjsonRequestServiceBuildRequest(t *testing.T) {
+	s := NewNdjsonRequestService().
+		Path("/_my_plugin/_ingest").
+		Add(map[string]interface{}{"index": map[string]interface{}{"_id": "1"}}, map[string]interface{}{"user": "olivere"})
+	req, err := s.buildRequest("http://127.0.0.1:9200")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "POST", req.Method; want != have {
+		t.Errorf("want method %q, have %q", want, have)
+	}
+	if want, have := "http://127.0.0.1:9200/_my_plugin/_ingest", req.URL.String(); want != have {
+		t.Errorf("want URL %q, have %q", want, have)
+	}
+	if want, have := "application/x-ndjson", req.Header.Get("Content-Type"); want != have {
+		t.Errorf("want Content-Type %q, have %q", want, have)
+	}
+	data, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	expected := `{"index":{"_id":"1"}}` + "\n" + `{"user":"olivere"}` + "\n"
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestNdjsonRequestServiceEncoder(t *testing.T) {
+	enc := &recordingEncoder{}
+	s := NewNdjsonRequestService().
+		Path("/_my_plugin/_ingest").
+		Encoder(enc).
+		Add(map[string]interface{}{"index": map[string]interface{}{"_id": "1"}}, `{"user":"olivere"}`)
+	req, err := s.buildRequest("http://127.0.0.1:9200")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The string line is used as is.
+	if want, have := 1, enc.calls; want != have {
+		t.Fatalf("want %d calls to encoder, have %d", want, have)
+	}
+	data, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := string(data)
+	expected := `{"index":{"_id":"1"}}` + "\n" + `{"user":"olivere"}` + "\n"
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestNdjsonRequestServiceValidate(t *testing.T) {
+	err := NewNdjsonRequestService().Validate()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if want, have := "missing required fields: [Path Lines]", err.Error(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestNdjsonRequestServiceDecodeResponse(t *testing.T) {
+	req, _ := http.NewRequest("POST", "http://127.0.0.1:9200/_my_plugin/_ingest", nil)
+	res := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(`{"took":3,"errors":false}`)),
+	}
+	var target struct {
+		Took   int  `json:"took"`
+		Errors bool `json:"errors"`
+	}
+	if err := NewNdjsonRequestService().decodeResponse(req, res, &target); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 3, target.Took; want != have {
+		t.Errorf("want took %d, have %d", want, have)
+	}
+	if target.Errors {
+		t.Error("want errors=false")
+	}
+}
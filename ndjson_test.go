@@ -0,0 +1,51 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestNdjsonBody(t *testing.T) {
+	body, err := ndjsonBody(
+		map[string]interface{}{"index": "test"},
+		`{"query":{"match_all":{}}}`,
+		json.RawMessage(`{"index":"test2"}`),
+		NewSearchSource().Size(0),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `{"index":"test"}
+{"query":{"match_all":{}}}
+{"index":"test2"}
+{"size":0}
+`
+	if body != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, body)
+	}
+	if !strings.HasSuffix(body, "\n") || strings.HasSuffix(body, "\n\n") {
+		t.Errorf("expected exactly one trailing newline; got %q", body)
+	}
+	for i, line := range strings.Split(strings.TrimSuffix(body, "\n"), "\n") {
+		if line == "" {
+			t.Errorf("expected line %d to be non-empty", i)
+		}
+	}
+}
+
+func TestNdjsonBodyRejectsInvalidLines(t *testing.T) {
+	tests := []interface{}{
+		"",
+		"{\n\"query\":{}\n}",
+	}
+	for i, line := range tests {
+		if _, err := ndjsonBody(line); err == nil {
+			t.Errorf("#%d: expected error for line %q", i, line)
+		}
+	}
+}
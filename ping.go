@@ -0,0 +1,132 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// PingService checks if an Elasticsearch server on a given URL is alive.
+// When asked for, it can also return various information about the
+// Elasticsearch server, e.g. the Elasticsearch version number.
+//
+// Ping simply starts a HTTP GET request to the URL of the server.
+// If the server responds with HTTP Status code 200 OK, the server is alive.
+type PingService struct {
+	url          string
+	timeout      string
+	httpHeadOnly bool
+	pretty       *bool
+}
+
+// PingResult is the result returned from querying the Elasticsearch server.
+type PingResult struct {
+	Name        string `json:"name"`
+	ClusterName string `json:"cluster_name"`
+	ClusterUUID string `json:"cluster_uuid"`
+	Version     struct {
+		Number                           string `json:"number"`                              // e.g. "7.0.0"
+		BuildFlavor                      string `json:"build_flavor"`                        // e.g. "oss" or "default"
+		BuildType                        string `json:"build_type"`                          // e.g. "docker"
+		BuildHash                        string `json:"build_hash"`                          // e.g. "b7e28a7"
+		BuildDate                        string `json:"build_date"`                          // e.g. "2019-04-05T22:55:32.697037Z"
+		BuildSnapshot                    bool   `json:"build_snapshot"`                      // e.g. false
+		LuceneVersion                    string `json:"lucene_version"`                      // e.g. "8.0.0"
+		MinimumWireCompatibilityVersion  string `json:"minimum_wire_compatibility_version"`  // e.g. "6.7.0"
+		MinimumIndexCompatibilityVersion string `json:"minimum_index_compatibility_version"` // e.g. "6.0.0-beta1"
+	} `json:"version"`
+	TagLine string `json:"tagline"`
+}
+
+// NewPingService creates a new PingService for the server at url.
+func NewPingService(url string) *PingService {
+	return &PingService{
+		url:          strings.TrimRight(url, "/"),
+		httpHeadOnly: false,
+	}
+}
+
+// URL sets the URL of the server to ping.
+func (s *PingService) URL(url string) *PingService {
+	s.url = strings.TrimRight(url, "/")
+	return s
+}
+
+// Timeout is the time to wait for the server to respond, e.g. "1s".
+func (s *PingService) Timeout(timeout string) *PingService {
+	s.timeout = timeout
+	return s
+}
+
+// HttpHeadOnly makes the service to only return the status code in Do;
+// the PingResult will be nil.
+func (s *PingService) HttpHeadOnly(httpHeadOnly bool) *PingService {
+	s.httpHeadOnly = httpHeadOnly
+	return s
+}
+
+// Pretty tells Elasticsearch whether to return a formatted JSON response.
+func (s *PingService) Pretty(pretty bool) *PingService {
+	s.pretty = &pretty
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *PingService) buildURL() (string, string, url.Values, error) {
+	method := "GET"
+	if s.httpHeadOnly {
+		method = "HEAD"
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if s.timeout != "" {
+		params.Set("timeout", s.timeout)
+	}
+	if v := s.pretty; v != nil {
+		params.Set("pretty", fmt.Sprint(*v))
+	}
+	return method, s.url + "/", params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *PingService) Validate() error {
+	var invalid []string
+	if s.url == "" {
+		invalid = append(invalid, "URL")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// ignoreErrors lists the HTTP status codes that do not fail a ping. A
+// secured cluster answers with 401 Unauthorized when no credentials are
+// given, which still tells that the server is alive.
+func (s *PingService) ignoreErrors() []int {
+	return []int{http.StatusUnauthorized}
+}
+
+// decodeResponse turns the HTTP response of a ping into a PingResult and
+// the HTTP status code. The PingResult is nil for HEAD requests and for
+// responses with an ignored error status.
+func (s *PingService) decodeResponse(req *http.Request, res *http.Response) (*PingResult, int, error) {
+	if err := checkResponse(req, res, s.ignoreErrors()...); err != nil {
+		return nil, res.StatusCode, err
+	}
+	if s.httpHeadOnly || res.StatusCode < 200 || res.StatusCode > 299 || res.Body == nil {
+		return nil, res.StatusCode, nil
+	}
+	ret := new(PingResult)
+	if err := json.NewDecoder(res.Body).Decode(ret); err != nil {
+		return nil, res.StatusCode, err
+	}
+	return ret, res.StatusCode, nil
+}
@@ -0,0 +1,130 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestPingServiceBuildURL(t *testing.T) {
+	tests := []struct {
+		Service *PingService
+		Method  string
+		Path    string
+		Params  string
+	}{
+		{NewPingService("http://127.0.0.1:9200"), "GET", "http://127.0.0.1:9200/", ""},
+		{NewPingService("http://127.0.0.1:9200/").Timeout("1s"), "GET", "http://127.0.0.1:9200/", "timeout=1s"},
+		{NewPingService("http://127.0.0.1:9200").HttpHeadOnly(true), "HEAD", "http://127.0.0.1:9200/", ""},
+	}
+	for i, tt := range tests {
+		method, path, params, err := tt.Service.buildURL()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if want, have := tt.Method, method; want != have {
+			t.Errorf("#%d: want method %q, have %q", i, want, have)
+		}
+		if want, have := tt.Path, path; want != have {
+			t.Errorf("#%d: want path %q, have %q", i, want, have)
+		}
+		if want, have := tt.Params, params.Encode(); want != have {
+			t.Errorf("#%d: want params %q, have %q", i, want, have)
+		}
+	}
+}
+
+func TestPingServiceValidate(t *testing.T) {
+	if err := NewPingService("").Validate(); err == nil {
+		t.Fatal("expected error for missing URL")
+	}
+	if err := NewPingService("http://127.0.0.1:9200").Validate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+func TestPingServiceDecodeResponse(t *testing.T) {
+	body := `{
+		"name" : "es1",
+		"cluster_name" : "elasticsearch",
+		"cluster_uuid" : "3SfT5lnPR4CQf5uCkFSB9w",
+		"version" : {
+			"number" : "7.17.9",
+			"build_flavor" : "default",
+			"build_type" : "docker",
+			"build_hash" : "ef48222227ee6b9e70e502f0f0daa52435ee634d",
+			"build_date" : "2023-01-31T05:34:43.305517834Z",
+			"build_snapshot" : false,
+			"lucene_version" : "8.11.1",
+			"minimum_wire_compatibility_version" : "6.8.0",
+			"minimum_index_compatibility_version" : "6.0.0-beta1"
+		},
+		"tagline" : "You Know, for Search"
+	}`
+	req, _ := http.NewRequest("GET", "http://127.0.0.1:9200/", nil)
+	res := &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+	result, code, err := NewPingService("http://127.0.0.1:9200").decodeResponse(req, res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := http.StatusOK, code; want != have {
+		t.Errorf("want status %d, have %d", want, have)
+	}
+	if result == nil {
+		t.Fatal("expected result")
+	}
+	if want, have := "es1", result.Name; want != have {
+		t.Errorf("want name %q, have %q", want, have)
+	}
+	if want, have := "elasticsearch", result.ClusterName; want != have {
+		t.Errorf("want cluster name %q, have %q", want, have)
+	}
+	if want, have := "3SfT5lnPR4CQf5uCkFSB9w", result.ClusterUUID; want != have {
+		t.Errorf("want cluster uuid %q, have %q", want, have)
+	}
+	if want, have := "7.17.9", result.Version.Number; want != have {
+		t.Errorf("want version %q, have %q", want, have)
+	}
+}
+
+func TestPingServiceDecodeResponseUnauthorized(t *testing.T) {
+	body := `{"error":{"type":"security_exception","reason":"missing authentication credentials"},"status":401}`
+	req, _ := http.NewRequest("GET", "http://127.0.0.1:9200/", nil)
+	res := &http.Response{
+		StatusCode: http.StatusUnauthorized,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+	result, code, err := NewPingService("http://127.0.0.1:9200").decodeResponse(req, res)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if want, have := http.StatusUnauthorized, code; want != have {
+		t.Errorf("want status %d, have %d", want, have)
+	}
+	if result != nil {
+		t.Errorf("expected no result, got %+v", result)
+	}
+}
+
+func TestPingServiceDecodeResponseError(t *testing.T) {
+	req, _ := http.NewRequest("GET", "http://127.0.0.1:9200/", nil)
+	res := &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Body:       ioutil.NopCloser(strings.NewReader(`{"status":503}`)),
+	}
+	_, code, err := NewPingService("http://127.0.0.1:9200").decodeResponse(req, res)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if want, have := http.StatusServiceUnavailable, code; want != have {
+		t.Errorf("want status %d, have %d", want, have)
+	}
+}
@@ -11,3 +11,24 @@ type Query interface {
 	// Source returns the JSON-serializable query request.
 	Source() (interface{}, error)
 }
+
+// NamedQuery is implemented by queries that support a name via QueryName.
+// The name is returned in the matched_queries of a search hit.
+type NamedQuery interface {
+	Query
+
+	// GetQueryName returns the name of the query, or an empty string
+	// if no name is set.
+	GetQueryName() string
+}
+
+// QueryNameOf returns the name of query q, if q supports names and a
+// name has been set.
+func QueryNameOf(q Query) (string, bool) {
+	nq, ok := q.(NamedQuery)
+	if !ok {
+		return "", false
+	}
+	name := nq.GetQueryName()
+	return name, name != ""
+}
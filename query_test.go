@@ -0,0 +1,30 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "testing"
+
+func TestQueryNameOf(t *testing.T) {
+	tests := []struct {
+		Query Query
+		Name  string
+		Found bool
+	}{
+		{NewTermQuery("user", "olivere").QueryName("by_user"), "by_user", true},
+		{NewBoolQuery().Must(NewMatchAllQuery()).QueryName("all"), "all", true},
+		{NewRangeQuery("retweets").Gte(10).QueryName("popular"), "popular", true},
+		{NewTermQuery("user", "olivere"), "", false},
+		{NewRawStringQuery(`{"match_all":{}}`), "", false},
+	}
+	for i, tt := range tests {
+		name, found := QueryNameOf(tt.Query)
+		if want, have := tt.Found, found; want != have {
+			t.Errorf("#%d: want found=%v, have %v", i, want, have)
+		}
+		if want, have := tt.Name, name; want != have {
+			t.Errorf("#%d: want name %q, have %q", i, want, have)
+		}
+	}
+}
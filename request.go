@@ -7,7 +7,6 @@ package elastic
 import (
 	"bytes"
 	"compress/gzip"
-	"encoding/json"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -36,23 +35,34 @@ func (r *Request) SetBasicAuth(username, password string) {
 // SetBody encodes the body in the request. You may pass a flag to
 // compress the request via gzip.
 func (r *Request) SetBody(body interface{}, gzipCompress bool) error {
+	return r.SetBodyWithEncoder(body, gzipCompress, nil)
+}
+
+// SetBodyWithEncoder is like SetBody but uses enc to encode bodies that
+// are not strings, e.g. to plug in a faster JSON encoder. If enc is nil,
+// DefaultEncoder is used. When compressing via gzip, the encoded bytes
+// are compressed.
+func (r *Request) SetBodyWithEncoder(body interface{}, gzipCompress bool, enc Encoder) error {
+	if enc == nil {
+		enc = &DefaultEncoder{}
+	}
 	switch b := body.(type) {
 	case string:
 		if gzipCompress {
-			return r.setBodyGzip(b)
+			return r.setBodyGzip(b, enc)
 		}
 		return r.setBodyString(b)
 	default:
 		if gzipCompress {
-			return r.setBodyGzip(body)
+			return r.setBodyGzip(body, enc)
 		}
-		return r.setBodyJson(body)
+		return r.setBodyJson(body, enc)
 	}
 }
 
-// setBodyJson encodes the body as a struct to be marshaled via json.Marshal.
-func (r *Request) setBodyJson(data interface{}) error {
-	body, err := json.Marshal(data)
+// setBodyJson encodes the body as a struct to be marshaled via enc.
+func (r *Request) setBodyJson(data interface{}, enc Encoder) error {
+	body, err := enc.Encode(data)
 	if err != nil {
 		return err
 	}
@@ -67,8 +77,8 @@ func (r *Request) setBodyString(body string) error {
 }
 
 // setBodyGzip gzip's the body. It accepts both strings and structs as body.
-// The latter will be encoded via json.Marshal.
-func (r *Request) setBodyGzip(body interface{}) error {
+// The latter will be encoded via enc.
+func (r *Request) setBodyGzip(body interface{}, enc Encoder) error {
 	switch b := body.(type) {
 	case string:
 		buf := new(bytes.Buffer)
@@ -83,7 +93,7 @@ func (r *Request) setBodyGzip(body interface{}) error {
 		r.Header.Add("Vary", "Accept-Encoding")
 		return r.setBodyReader(bytes.NewReader(buf.Bytes()))
 	default:
-		data, err := json.Marshal(b)
+		data, err := enc.Encode(b)
 		if err != nil {
 			return err
 		}
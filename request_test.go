@@ -4,7 +4,13 @@
 
 package elastic
 
-import "testing"
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+)
 
 var testReq *Request // used as a temporary variable to avoid compiler optimizations in tests/benchmarks
 
@@ -25,6 +31,89 @@ func TestRequestSetContentType(t *testing.T) {
 	}
 }
 
+// recordingEncoder counts how often it was used to encode a body.
+type recordingEncoder struct {
+	calls int
+}
+
+func (e *recordingEncoder) Encode(v interface{}) ([]byte, error) {
+	e.calls++
+	return json.Marshal(v)
+}
+
+func TestRequestSetBodyWithEncoder(t *testing.T) {
+	body := map[string]interface{}{
+		"query": map[string]interface{}{
+			"match_all": map[string]interface{}{},
+		},
+	}
+	expected := `{"query":{"match_all":{}}}`
+
+	// Plain
+	enc := &recordingEncoder{}
+	req, err := NewRequest("POST", "/_search")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := req.SetBodyWithEncoder(body, false, enc); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 1, enc.calls; want != have {
+		t.Fatalf("want %d calls to encoder, have %d", want, have)
+	}
+	data, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+
+	// Gzip
+	enc = &recordingEncoder{}
+	req, err = NewRequest("POST", "/_search")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := req.SetBodyWithEncoder(body, true, enc); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 1, enc.calls; want != have {
+		t.Fatalf("want %d calls to encoder, have %d", want, have)
+	}
+	if want, have := "gzip", req.Header.Get("Content-Encoding"); want != have {
+		t.Fatalf("want Content-Encoding=%q, have %q", want, have)
+	}
+	compressed, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zr, err := gzip.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err = ioutil.ReadAll(zr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(data); got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+
+	// Strings are passed through as-is
+	enc = &recordingEncoder{}
+	req, err = NewRequest("POST", "/_search")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := req.SetBodyWithEncoder(expected, false, enc); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 0, enc.calls; want != have {
+		t.Fatalf("want %d calls to encoder, have %d", want, have)
+	}
+}
+
 func BenchmarkRequestSetBodyString(b *testing.B) {
 	req, err := NewRequest("GET", "/")
 	if err != nil {
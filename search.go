@@ -5,15 +5,43 @@
 package elastic
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// defaultSearchTimeoutMargin is added to the server-side timeout of a
+// search to derive the deadline of the HTTP request. See TimeoutContext.
+const defaultSearchTimeoutMargin = 1 * time.Second
+
 // Search for documents in Elasticsearch.
 type SearchService struct {
+	pretty     *bool    // pretty format the returned JSON response
+	human      *bool    // return human readable values for statistics
+	errorTrace *bool    // include the stack trace of returned errors
+	filterPath []string // list of filters used to reduce the response
+
+	index                      []string
 	searchSource               *SearchSource // q
 	source                     interface{}
+	searchType                 string // search_type
+	batchedReduceSize          *int
+	maxConcurrentShardRequests *int
+	ignoreUnavailable          *bool
+	allowNoIndices             *bool
+	expandWildcards            string
+	compress                   *bool
+	timeoutMargin              *time.Duration
+	skipRescoreWindowCheck     bool
+	productCheck               bool
+	encoder                    Encoder
 }
 
 // NewSearchService creates a new service for searching in Elasticsearch.
@@ -24,6 +52,57 @@ func NewSearchService() *SearchService {
 	return builder
 }
 
+// Pretty tells Elasticsearch whether to return a formatted JSON response.
+func (s *SearchService) Pretty(pretty bool) *SearchService {
+	s.pretty = &pretty
+	return s
+}
+
+// Human specifies whether human readable values should be returned in
+// the JSON response, e.g. "7.5mb".
+func (s *SearchService) Human(human bool) *SearchService {
+	s.human = &human
+	return s
+}
+
+// ErrorTrace specifies whether to include the stack trace of returned errors.
+func (s *SearchService) ErrorTrace(errorTrace bool) *SearchService {
+	s.errorTrace = &errorTrace
+	return s
+}
+
+// FilterPath specifies a list of filters used to reduce the response.
+// Notice that parts of the response not matched by the filters, e.g.
+// _shards or hits.total, are missing from the SearchResult then; its
+// fields are left zero or nil and helpers like TotalHits return 0.
+func (s *SearchService) FilterPath(filterPath ...string) *SearchService {
+	s.filterPath = filterPath
+	return s
+}
+
+// ProductCheck enables checking that a successful response carries the
+// "X-Elastic-Product: Elasticsearch" header, returning ErrUnsupportedProduct
+// otherwise. It is disabled by default, as Elasticsearch sends the header
+// only since 7.14.
+func (s *SearchService) ProductCheck(enabled bool) *SearchService {
+	s.productCheck = enabled
+	return s
+}
+
+// Encoder sets the encoder used to serialize the request body.
+// If not set, DefaultEncoder is used.
+func (s *SearchService) Encoder(enc Encoder) *SearchService {
+	s.encoder = enc
+	return s
+}
+
+// Index sets the names of the indices to use for search.
+// Notice that indices must not be set when searching with a PointInTime.
+func (s *SearchService) Index(index ...string) *SearchService {
+	s.index = append(s.index, index...)
+	return s
+}
+
 // SearchSource sets the search source builder to use with this service.
 func (s *SearchService) SearchSource(searchSource *SearchSource) *SearchService {
 	s.searchSource = searchSource
@@ -40,12 +119,94 @@ func (s *SearchService) Source(source interface{}) *SearchService {
 	return s
 }
 
+// Compress enables or disables gzip compression of the request body
+// for this request only, e.g. for queries with a huge terms filter.
+// If not set, the body is sent uncompressed.
+func (s *SearchService) Compress(compress bool) *SearchService {
+	s.compress = &compress
+	return s
+}
+
+// SearchType sets the search operation type. Valid values are:
+// "dfs_query_then_fetch" and "query_then_fetch".
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/search-request-search-type.html
+// for details.
+func (s *SearchService) SearchType(searchType string) *SearchService {
+	s.searchType = searchType
+	return s
+}
+
+// BatchedReduceSize specifies the number of shard results that should be
+// reduced at once on the coordinating node. This value should be used
+// as a protection mechanism to reduce the memory overhead per search
+// request if the potential number of shards in the request can be large.
+func (s *SearchService) BatchedReduceSize(size int) *SearchService {
+	s.batchedReduceSize = &size
+	return s
+}
+
+// MaxConcurrentShardRequests specifies the number of concurrent
+// shard requests this search executes concurrently. This value should
+// be used to limit the impact of the search on the cluster in order
+// to limit the number of concurrent shard requests.
+func (s *SearchService) MaxConcurrentShardRequests(max int) *SearchService {
+	s.maxConcurrentShardRequests = &max
+	return s
+}
+
+// IgnoreUnavailable indicates whether the specified concrete indices
+// should be ignored when unavailable (missing or closed).
+func (s *SearchService) IgnoreUnavailable(ignoreUnavailable bool) *SearchService {
+	s.ignoreUnavailable = &ignoreUnavailable
+	return s
+}
+
+// AllowNoIndices indicates whether to ignore if a wildcard indices
+// expression resolves into no concrete indices. (This includes `_all` string
+// or when no indices have been specified).
+func (s *SearchService) AllowNoIndices(allowNoIndices bool) *SearchService {
+	s.allowNoIndices = &allowNoIndices
+	return s
+}
+
+// ExpandWildcards indicates whether to expand wildcard expression to
+// concrete indices that are open, closed or both.
+func (s *SearchService) ExpandWildcards(expandWildcards string) *SearchService {
+	s.expandWildcards = expandWildcards
+	return s
+}
+
 // Timeout sets the timeout to use, e.g. "1s" or "1000ms".
 func (s *SearchService) Timeout(timeout string) *SearchService {
 	s.searchSource = s.searchSource.Timeout(timeout)
 	return s
 }
 
+// TimeoutMargin sets the time added to the server-side timeout (see
+// Timeout) when deriving the deadline of the HTTP request in
+// TimeoutContext. It defaults to 1 second.
+func (s *SearchService) TimeoutMargin(margin time.Duration) *SearchService {
+	s.timeoutMargin = &margin
+	return s
+}
+
+// TimeoutContext derives a context for the HTTP request of the search.
+// If a server-side timeout is set via Timeout or TimeoutInMillis, the
+// returned context has a deadline of that timeout plus the margin set
+// via TimeoutMargin, so the HTTP call does not outlive the search by
+// much. A deadline already set on ctx is never extended.
+func (s *SearchService) TimeoutContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	timeout, err := parseTimeValue(s.searchSource.timeout)
+	if err != nil || timeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	margin := defaultSearchTimeoutMargin
+	if s.timeoutMargin != nil {
+		margin = *s.timeoutMargin
+	}
+	return context.WithTimeout(ctx, timeout+margin)
+}
+
 // Profile sets the Profile API flag on the search source.
 // When enabled, a search executed by this service will return query
 // profiling data.
@@ -67,6 +228,20 @@ func (s *SearchService) PointInTime(pointInTime *PointInTime) *SearchService {
 	return s
 }
 
+// KNN adds one or more approximate k-nearest neighbor searches.
+// See SearchSource.KNN for details.
+func (s *SearchService) KNN(queries ...*KnnQuery) *SearchService {
+	s.searchSource = s.searchSource.KNN(queries...)
+	return s
+}
+
+// Retriever sets the retriever to return the top documents.
+// See SearchSource.Retriever for details.
+func (s *SearchService) Retriever(retriever Retriever) *SearchService {
+	s.searchSource = s.searchSource.Retriever(retriever)
+	return s
+}
+
 // RuntimeMappings specifies optional runtime mappings.
 func (s *SearchService) RuntimeMappings(runtimeMappings RuntimeMappings) *SearchService {
 	s.searchSource = s.searchSource.RuntimeMappings(runtimeMappings)
@@ -100,6 +275,18 @@ func (s *SearchService) PostFilter(postFilter Query) *SearchService {
 	return s
 }
 
+// ClearQuery removes a query previously set via Query.
+func (s *SearchService) ClearQuery() *SearchService {
+	s.searchSource = s.searchSource.ClearQuery()
+	return s
+}
+
+// ClearPostFilter removes a post filter previously set via PostFilter.
+func (s *SearchService) ClearPostFilter() *SearchService {
+	s.searchSource = s.searchSource.ClearPostFilter()
+	return s
+}
+
 // FetchSource indicates whether the response should contain the stored
 // _source for every hit.
 func (s *SearchService) FetchSource(fetchSource bool) *SearchService {
@@ -113,6 +300,18 @@ func (s *SearchService) FetchSourceContext(fetchSourceContext *FetchSourceContex
 	return s
 }
 
+// SourceIncludes specifies fields of _source to be returned with each hit.
+func (s *SearchService) SourceIncludes(fields ...string) *SearchService {
+	s.searchSource = s.searchSource.SourceIncludes(fields...)
+	return s
+}
+
+// SourceExcludes specifies fields of _source to be omitted from each hit.
+func (s *SearchService) SourceExcludes(fields ...string) *SearchService {
+	s.searchSource = s.searchSource.SourceExcludes(fields...)
+	return s
+}
+
 // Highlight adds highlighting to the search.
 func (s *SearchService) Highlight(highlight *Highlight) *SearchService {
 	s.searchSource = s.searchSource.Highlight(highlight)
@@ -216,6 +415,19 @@ func (s *SearchService) DocvalueFieldsWithFormat(docvalueFields ...DocvalueField
 	return s
 }
 
+// Field adds a single field to retrieve via the fields API, formatted
+// according to format (which may be empty).
+func (s *SearchService) Field(name, format string) *SearchService {
+	s.searchSource = s.searchSource.Field(name, format)
+	return s
+}
+
+// Fields adds one or more fields to retrieve via the fields API.
+func (s *SearchService) Fields(fields ...FieldAndFormat) *SearchService {
+	s.searchSource = s.searchSource.Fields(fields...)
+	return s
+}
+
 // NoStoredFields indicates that no stored fields should be loaded, resulting in only
 // id and type to be returned per field.
 func (s *SearchService) NoStoredFields() *SearchService {
@@ -223,6 +435,14 @@ func (s *SearchService) NoStoredFields() *SearchService {
 	return s
 }
 
+// StoredFieldsNone disables loading of stored fields and metadata
+// fields like _id entirely by setting stored_fields to "_none_".
+// This is useful e.g. for requests that only return aggregations.
+func (s *SearchService) StoredFieldsNone() *SearchService {
+	s.searchSource = s.searchSource.StoredFieldsNone()
+	return s
+}
+
 // StoredField adds a single field to load and return (note, must be stored) as
 // part of the search request. If none are specified, the source of the
 // document will be returned.
@@ -276,6 +496,201 @@ func (s *SearchService) Rescorer(rescore *Rescore) *SearchService {
 	return s
 }
 
+// ValidateRescoreWindow enables or disables the check in Validate, and
+// hence when building the request, that the window size of every rescore
+// is at least from+size. It is enabled by default.
+//
+// Only window sizes that are set explicitly, via Rescore.WindowSize or
+// DefaultRescoreWindowSize, are checked. A rescore without a window size
+// is not rejected even if from+size exceeds the Elasticsearch default of
+// 10, as Elasticsearch accepts it and rescores the top 10 hits only.
+func (s *SearchService) ValidateRescoreWindow(enabled bool) *SearchService {
+	s.skipRescoreWindowCheck = !enabled
+	return s
+}
+
+// Stats specifies the stats groups this search will be aggregated under
+// in the indices stats API.
+func (s *SearchService) Stats(statsGroup ...string) *SearchService {
+	s.searchSource = s.searchSource.Stats(statsGroup...)
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *SearchService) buildURL() (string, url.Values, error) {
+	path := "/_search"
+	if len(s.index) > 0 {
+		escaped := make([]string, len(s.index))
+		for i, index := range s.index {
+			escaped[i] = url.PathEscape(index)
+		}
+		path = "/" + strings.Join(escaped, ",") + path
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if v := s.pretty; v != nil {
+		params.Set("pretty", fmt.Sprint(*v))
+	}
+	if v := s.human; v != nil {
+		params.Set("human", fmt.Sprint(*v))
+	}
+	if v := s.errorTrace; v != nil {
+		params.Set("error_trace", fmt.Sprint(*v))
+	}
+	if len(s.filterPath) > 0 {
+		params.Set("filter_path", strings.Join(s.filterPath, ","))
+	}
+	if s.searchType != "" {
+		params.Set("search_type", s.searchType)
+	}
+	if v := s.batchedReduceSize; v != nil {
+		params.Set("batched_reduce_size", fmt.Sprint(*v))
+	}
+	if v := s.maxConcurrentShardRequests; v != nil {
+		params.Set("max_concurrent_shard_requests", fmt.Sprint(*v))
+	}
+	if v := s.ignoreUnavailable; v != nil {
+		params.Set("ignore_unavailable", fmt.Sprint(*v))
+	}
+	if v := s.allowNoIndices; v != nil {
+		params.Set("allow_no_indices", fmt.Sprint(*v))
+	}
+	if s.expandWildcards != "" {
+		params.Set("expand_wildcards", s.expandWildcards)
+	}
+	return path, params, nil
+}
+
+// body returns the request body, preferring a body set via Source.
+// The JSON of a frozen search source is used as-is.
+func (s *SearchService) body() (interface{}, error) {
+	if s.source != nil {
+		return s.source, nil
+	}
+	if s.searchSource.frozen {
+		data, err := s.searchSource.SourceJSON()
+		if err != nil {
+			return nil, err
+		}
+		return json.RawMessage(data), nil
+	}
+	return s.searchSource.Source()
+}
+
+// buildRequest validates the operation and builds the HTTP request for it
+// against baseURL, compressing the body if requested via Compress.
+func (s *SearchService) buildRequest(baseURL string) (*Request, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	path, params, err := s.buildURL()
+	if err != nil {
+		return nil, err
+	}
+	u := baseURL + path
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	req, err := NewRequest("POST", u)
+	if err != nil {
+		return nil, err
+	}
+	body, err := s.body()
+	if err != nil {
+		return nil, err
+	}
+	gzipCompress := s.compress != nil && *s.compress
+	if err := req.SetBodyWithEncoder(body, gzipCompress, s.encoder); err != nil {
+		return nil, err
+	}
+	return req, nil
+}
+
+// decodeResponse turns the HTTP response of a search into a SearchResult.
+// Status and Header are populated from the HTTP response, so Status is set
+// for single searches just like for the responses of a MultiSearch.
+func (s *SearchService) decodeResponse(req *http.Request, res *http.Response) (*SearchResult, error) {
+	if err := checkResponse(req, res); err != nil {
+		return nil, err
+	}
+	if s.productCheck {
+		if err := checkProductHeader(res); err != nil {
+			return nil, err
+		}
+	}
+	ret := new(SearchResult)
+	if res.Body != nil {
+		if err := json.NewDecoder(res.Body).Decode(ret); err != nil {
+			return nil, err
+		}
+	}
+	ret.Header = res.Header
+	if ret.Status == 0 {
+		ret.Status = res.StatusCode
+	}
+	return ret, nil
+}
+
+// timeUnits maps Elasticsearch time units to durations.
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/common-options.html#time-units.
+var timeUnits = []struct {
+	suffix string
+	unit   time.Duration
+}{
+	// Order matters: "ms" must be checked before "s"
+	{"nanos", time.Nanosecond},
+	{"micros", time.Microsecond},
+	{"ms", time.Millisecond},
+	{"d", 24 * time.Hour},
+	{"h", time.Hour},
+	{"m", time.Minute},
+	{"s", time.Second},
+}
+
+// parseTimeValue parses an Elasticsearch time value like "1s" or "500ms".
+// An empty string returns 0.
+func parseTimeValue(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	for _, tu := range timeUnits {
+		if !strings.HasSuffix(value, tu.suffix) {
+			continue
+		}
+		n, err := strconv.ParseInt(strings.TrimSuffix(value, tu.suffix), 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("elastic: invalid time value %q", value)
+		}
+		return time.Duration(n) * tu.unit, nil
+	}
+	return 0, fmt.Errorf("elastic: invalid time value %q", value)
+}
+
+// Validate checks if the operation is valid.
+func (s *SearchService) Validate() error {
+	switch s.searchType {
+	case "", "dfs_query_then_fetch", "query_then_fetch":
+	default:
+		return fmt.Errorf("elastic: invalid search type %q", s.searchType)
+	}
+	if s.source == nil {
+		if len(s.index) > 0 && s.searchSource.pointInTime != nil {
+			return errors.New("elastic: indices must not be set when searching with a point in time")
+		}
+		if err := s.searchSource.Validate(); err != nil {
+			return err
+		}
+		if !s.skipRescoreWindowCheck {
+			if err := s.searchSource.validateRescoreWindow(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // SearchResult is the result of a search in Elasticsearch.
 // FIXME: Is this up-to-date?
 type SearchResult struct {
@@ -292,7 +707,7 @@ type SearchResult struct {
 	Error           *ErrorDetails        `json:"error,omitempty"`        // only used in MultiGet
 	Profile         *SearchProfile       `json:"profile,omitempty"`      // profiling results, if optional Profile API was active for this search
 	Shards          *ShardsInfo          `json:"_shards,omitempty"`      // shard information
-	Status          int                  `json:"status,omitempty"`       // used in MultiSearch
+	Status          int                  `json:"status,omitempty"`       // HTTP status code
 	PitId           string               `json:"pit_id,omitempty"`       // Point In Time ID
 }
 
@@ -314,6 +729,42 @@ func (r *SearchResult) TotalHits() int64 {
 	return 0
 }
 
+// NextPointInTime returns a PointInTime with the PitId of this result and
+// the given keep alive, e.g. "1m", to be used in the next search of a
+// search_after loop. It returns nil if the result has no PitId.
+func (r *SearchResult) NextPointInTime(keepAlive string) *PointInTime {
+	if r == nil || r.PitId == "" {
+		return nil
+	}
+	return NewPointInTimeWithKeepAlive(r.PitId, keepAlive)
+}
+
+// Err returns the error of a failed search as reported in the Error
+// field, e.g. for a single response of a multi-search, or nil if the
+// search succeeded.
+func (r *SearchResult) Err() error {
+	if r == nil || r.Error == nil {
+		return nil
+	}
+	return &Error{Status: r.Status, Details: r.Error}
+}
+
+// HasProfile returns true if the search result contains profiling data.
+//
+// Notice that the result does not tell whether profiling was requested.
+// If Profile(true) was set on the search but HasProfile returns false,
+// the profile block was most probably stripped from the response, e.g.
+// by a proxy or a filter_path that doesn't include it.
+func (r *SearchResult) HasProfile() bool {
+	return r != nil && r.Profile != nil
+}
+
+// DidTimeout returns true if the search timed out on the server side,
+// i.e. the results are partial. See IsTimeout for client-side timeouts.
+func (r *SearchResult) DidTimeout() bool {
+	return r != nil && r.TimedOut
+}
+
 // Each is a utility function to iterate over all hits. It saves you from
 // checking for nil values. Notice that Each will ignore errors in
 // serializing JSON and hits with empty/nil _source will get an empty
@@ -404,6 +855,48 @@ type SearchHit struct {
 	Node           string                         `json:"_node,omitempty"`           // used e.g. in Search Explain
 }
 
+// DataStream returns the name of the data stream the hit belongs to, if
+// its index is a data stream backing index, e.g. "logs-nginx" for
+// ".ds-logs-nginx-2099.03.07-000001". Elasticsearch does not return the
+// data stream name with a hit, so it is derived from the backing index
+// name. Hits from an index behind a rollover alias cannot be resolved
+// this way; looking up the alias requires a separate request.
+func (h *SearchHit) DataStream() (string, bool) {
+	const prefix = ".ds-"
+	if h == nil || !strings.HasPrefix(h.Index, prefix) {
+		return "", false
+	}
+	name := strings.TrimPrefix(h.Index, prefix)
+
+	// Strip generation, e.g. "-000001"
+	i := strings.LastIndexByte(name, '-')
+	if i <= 0 || !isDigits(name[i+1:]) {
+		return "", false
+	}
+	name = name[:i]
+
+	// Strip date, e.g. "-2099.03.07", which was added in 7.11
+	if i := strings.LastIndexByte(name, '-'); i > 0 {
+		if date := name[i+1:]; len(date) == 10 && isDigits(strings.Replace(date, ".", "", 2)) && strings.Count(date, ".") == 2 {
+			name = name[:i]
+		}
+	}
+	return name, true
+}
+
+// isDigits returns true if s is a non-empty string of ASCII digits.
+func isDigits(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // SearchHitFields helps to simplify resolving slices of specific types.
 type SearchHitFields map[string]interface{}
 
@@ -446,6 +939,36 @@ type SearchHitInnerHits struct {
 	Hits *SearchHits `json:"hits,omitempty"`
 }
 
+// InnerHitTotal is a convenience function to return the total number of
+// inner hits with the given name. It returns 0 if there are no such
+// inner hits.
+func (h *SearchHit) InnerHitTotal(name string) int64 {
+	if hits := h.innerHits(name); hits != nil && hits.TotalHits != nil {
+		return hits.TotalHits.Value
+	}
+	return 0
+}
+
+// InnerHitMaxScore is a convenience function to return the maximum score
+// of the inner hits with the given name. It returns nil if there are no
+// such inner hits or they are not scored.
+func (h *SearchHit) InnerHitMaxScore(name string) *float64 {
+	if hits := h.innerHits(name); hits != nil {
+		return hits.MaxScore
+	}
+	return nil
+}
+
+func (h *SearchHit) innerHits(name string) *SearchHits {
+	if h == nil || h.InnerHits == nil {
+		return nil
+	}
+	if inner := h.InnerHits[name]; inner != nil {
+		return inner.Hits
+	}
+	return nil
+}
+
 // SearchExplanation explains how the score for a hit was computed.
 // See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/search-request-explain.html.
 type SearchExplanation struct {
@@ -491,6 +1014,50 @@ type SearchProfile struct {
 	Shards []SearchProfileShardResult `json:"shards"`
 }
 
+// SearchProfileShardTotals summarizes the profiling timings of a single
+// shard, as returned by SearchProfile.ShardTotals.
+type SearchProfileShardTotals struct {
+	ID             string // shard identifier, e.g. "[nodeId][index][0]"
+	QueryNanos     int64  // total time spent in queries
+	RewriteNanos   int64  // total time spent rewriting queries
+	CollectorNanos int64  // total time spent in collectors
+}
+
+// ShardTotals sums up the query, rewrite, and collector timings of each
+// shard in the profile. Only the top-level query and collector nodes are
+// summed up, as their timings already include those of their children.
+func (p *SearchProfile) ShardTotals() []SearchProfileShardTotals {
+	if p == nil {
+		return nil
+	}
+	totals := make([]SearchProfileShardTotals, 0, len(p.Shards))
+	for _, shard := range p.Shards {
+		t := SearchProfileShardTotals{ID: shard.ID}
+		for _, search := range shard.Searches {
+			for _, q := range search.Query {
+				t.QueryNanos += q.NodeTimeNanos
+			}
+			t.RewriteNanos += search.RewriteTime
+			for _, c := range search.Collector {
+				m, ok := c.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				switch v := m["time_in_nanos"].(type) {
+				case float64:
+					t.CollectorNanos += int64(v)
+				case json.Number:
+					if n, err := v.Int64(); err == nil {
+						t.CollectorNanos += n
+					}
+				}
+			}
+		}
+		totals = append(totals, t)
+	}
+	return totals
+}
+
 // SearchProfileShardResult returns the profiling data for a single shard
 // accessed during the search query or aggregation.
 type SearchProfileShardResult struct {
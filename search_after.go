@@ -0,0 +1,42 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+)
+
+// EncodeSearchAfter encodes the sort values of a search hit, e.g. the
+// Sort field of the last SearchHit of a page, into an opaque cursor
+// that can safely be passed around, e.g. in a URL query string.
+// Use DecodeSearchAfter to get the sort values back and pass them
+// to SearchAfter.
+func EncodeSearchAfter(values []interface{}) (string, error) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// DecodeSearchAfter decodes a cursor created by EncodeSearchAfter into
+// the sort values to pass to SearchAfter. Numbers are decoded as
+// json.Number to preserve the precision of large integers, e.g. of
+// dates or tie breakers like _shard_doc.
+func DecodeSearchAfter(cursor string) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var values []interface{}
+	if err := dec.Decode(&values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
@@ -0,0 +1,73 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+)
+
+func TestSearchAfterEncodeDecode(t *testing.T) {
+	values := []interface{}{int64(9223372036854775807), "tweet#42", 1.5, nil}
+	cursor, err := EncodeSearchAfter(values)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := cursor, url.QueryEscape(cursor); want != have {
+		t.Fatalf("expected cursor to be safe in query strings; got: %q", cursor)
+	}
+
+	decoded, err := DecodeSearchAfter(cursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := len(values), len(decoded); want != have {
+		t.Fatalf("expected %d values; got: %d", want, have)
+	}
+	n, ok := decoded[0].(json.Number)
+	if !ok {
+		t.Fatalf("expected json.Number; got: %T", decoded[0])
+	}
+	if want, have := "9223372036854775807", n.String(); want != have {
+		t.Fatalf("expected %s; got: %s", want, have)
+	}
+	if want, have := "tweet#42", decoded[1]; want != have {
+		t.Fatalf("expected %v; got: %v", want, have)
+	}
+	if want, have := json.Number("1.5"), decoded[2]; want != have {
+		t.Fatalf("expected %v; got: %v", want, have)
+	}
+	if decoded[3] != nil {
+		t.Fatalf("expected nil; got: %v", decoded[3])
+	}
+
+	// Decoded values serialize to the original sort values
+	src, err := NewSearchSource().SearchAfter(decoded...).Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"search_after":[9223372036854775807,"tweet#42",1.5,null]}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchAfterDecodeInvalid(t *testing.T) {
+	tests := []string{
+		"not base64!",
+		"eyJhIjoxfQ", // {"a":1}
+	}
+	for i, cursor := range tests {
+		if _, err := DecodeSearchAfter(cursor); err == nil {
+			t.Errorf("#%d: expected error for %q", i, cursor)
+		}
+	}
+}
@@ -144,6 +144,21 @@ func (a Aggregations) Cardinality(name string) (*AggregationValueMetric, bool) {
 	return nil, false
 }
 
+// Rate returns rate aggregation results.
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-metrics-rate-aggregation.html
+func (a Aggregations) Rate(name string) (*AggregationValueMetric, bool) {
+	if raw, found := a[name]; found {
+		agg := new(AggregationValueMetric)
+		if raw == nil {
+			return agg, true
+		}
+		if err := json.Unmarshal(raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
 // Stats returns stats aggregation results.
 // https://www.elastic.co/guide/en/elasticsearch/reference/7.0/search-aggregations-metrics-stats-aggregation.html
 func (a Aggregations) Stats(name string) (*AggregationStatsMetric, bool) {
@@ -399,6 +414,21 @@ func (a Aggregations) RareTerms(name string) (*AggregationBucketKeyItems, bool)
 	return nil, false
 }
 
+// CategorizeText returns categorize text aggregation results.
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/current/search-aggregations-bucket-categorize-text-aggregation.html
+func (a Aggregations) CategorizeText(name string) (*AggregationBucketCategorizeTextItems, bool) {
+	if raw, found := a[name]; found {
+		agg := new(AggregationBucketCategorizeTextItems)
+		if raw == nil {
+			return agg, true
+		}
+		if err := json.Unmarshal(raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
 // Sampler returns sampler aggregation results.
 // See: https://www.elastic.co/guide/en/elasticsearch/reference/7.0/search-aggregations-bucket-sampler-aggregation.html
 func (a Aggregations) Sampler(name string) (*AggregationSingleBucket, bool) {
@@ -534,6 +564,21 @@ func (a Aggregations) DateHistogram(name string) (*AggregationBucketHistogramIte
 	return nil, false
 }
 
+// VariableWidthHistogram returns variable width histogram aggregation results.
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/7.17/search-aggregations-bucket-variablewidthhistogram-aggregation.html
+func (a Aggregations) VariableWidthHistogram(name string) (*AggregationBucketVariableWidthHistogramItems, bool) {
+	if raw, found := a[name]; found {
+		agg := new(AggregationBucketVariableWidthHistogramItems)
+		if raw == nil {
+			return agg, true
+		}
+		if err := json.Unmarshal(raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
 // KeyedDateHistogram returns date histogram aggregation results for keyed responses.
 //
 // See: https://www.elastic.co/guide/en/elasticsearch/reference/7.0/search-aggregations-bucket-datehistogram-aggregation.html#_keyed_response_3
@@ -670,6 +715,21 @@ func (a Aggregations) StatsBucket(name string) (*AggregationPipelineStatsMetric,
 	return nil, false
 }
 
+// ExtendedStatsBucket returns extended stats bucket pipeline aggregation results.
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/search-aggregations-pipeline-extended-stats-bucket-aggregation.html
+func (a Aggregations) ExtendedStatsBucket(name string) (*AggregationPipelineStatsMetric, bool) {
+	if raw, found := a[name]; found {
+		agg := new(AggregationPipelineStatsMetric)
+		if raw == nil {
+			return agg, true
+		}
+		if err := json.Unmarshal(raw, agg); err == nil {
+			return agg, true
+		}
+	}
+	return nil, false
+}
+
 // PercentilesBucket returns stats bucket pipeline aggregation results.
 // See https://www.elastic.co/guide/en/elasticsearch/reference/7.0/search-aggregations-pipeline-percentiles-bucket-aggregation.html
 func (a Aggregations) PercentilesBucket(name string) (*AggregationPipelinePercentilesMetric, bool) {
@@ -856,6 +916,24 @@ func (a Aggregations) TopMetrics(name string) (*AggregationTopMetricsItems, bool
 	return nil, false
 }
 
+// UnmarshalAgg decodes the aggregation with the given name into v.
+// It is useful for aggregations that have no built-in accessor, e.g.
+// aggregations provided by plugins. The first return value indicates
+// whether an aggregation with the given name was found.
+func (a Aggregations) UnmarshalAgg(name string, v interface{}) (bool, error) {
+	raw, found := a[name]
+	if !found {
+		return false, nil
+	}
+	if raw == nil {
+		return true, nil
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
 // -- Single value metric --
 
 // AggregationValueMetric is a single-value metric, returned e.g. by a
@@ -1025,8 +1103,17 @@ func (a *AggregationMatrixStats) UnmarshalJSON(data []byte) error {
 type AggregationPercentilesMetric struct {
 	Aggregations
 
-	Values map[string]float64     // `json:"values"`
-	Meta   map[string]interface{} // `json:"meta,omitempty"`
+	Values     map[string]float64            // `json:"values"`
+	ValuesList []AggregationPercentilesValue // `json:"values"`, if keyed is false
+	Meta       map[string]interface{}        // `json:"meta,omitempty"`
+}
+
+// AggregationPercentilesValue is a single percentile, returned in
+// AggregationPercentilesMetric.ValuesList if the aggregation is not keyed.
+type AggregationPercentilesValue struct {
+	Key           float64 `json:"key"`
+	Value         float64 `json:"value"`
+	ValueAsString string  `json:"value_as_string,omitempty"`
 }
 
 // UnmarshalJSON decodes JSON data and initializes an AggregationPercentilesMetric structure.
@@ -1036,7 +1123,11 @@ func (a *AggregationPercentilesMetric) UnmarshalJSON(data []byte) error {
 		return err
 	}
 	if v, ok := aggs["values"]; ok && v != nil {
-		json.Unmarshal(v, &a.Values)
+		if v = bytes.TrimSpace(v); len(v) > 0 && v[0] == '[' {
+			json.Unmarshal(v, &a.ValuesList)
+		} else {
+			json.Unmarshal(v, &a.Values)
+		}
 	}
 	if v, ok := aggs["meta"]; ok && v != nil {
 		json.Unmarshal(v, &a.Meta)
@@ -1051,7 +1142,8 @@ func (a *AggregationPercentilesMetric) UnmarshalJSON(data []byte) error {
 type AggregationGeoBoundsMetric struct {
 	Aggregations
 
-	Bounds struct {
+	// Bounds is nil if the aggregation matched no documents.
+	Bounds *struct {
 		TopLeft struct {
 			Latitude  float64 `json:"lat"`
 			Longitude float64 `json:"lon"`
@@ -1147,10 +1239,11 @@ func (a *AggregationSingleBucket) UnmarshalJSON(data []byte) error {
 type AggregationBucketRangeItems struct {
 	Aggregations
 
-	DocCountErrorUpperBound int64                         //`json:"doc_count_error_upper_bound"`
-	SumOfOtherDocCount      int64                         //`json:"sum_other_doc_count"`
-	Buckets                 []*AggregationBucketRangeItem //`json:"buckets"`
-	Meta                    map[string]interface{}        // `json:"meta,omitempty"`
+	DocCountErrorUpperBound int64                                  //`json:"doc_count_error_upper_bound"`
+	SumOfOtherDocCount      int64                                  //`json:"sum_other_doc_count"`
+	Buckets                 []*AggregationBucketRangeItem          //`json:"buckets"`
+	NamedBuckets            map[string]*AggregationBucketRangeItem //`json:"buckets"`, if keyed
+	Meta                    map[string]interface{}                 // `json:"meta,omitempty"`
 }
 
 // UnmarshalJSON decodes JSON data and initializes an AggregationBucketRangeItems structure.
@@ -1167,6 +1260,7 @@ func (a *AggregationBucketRangeItems) UnmarshalJSON(data []byte) error {
 	}
 	if v, ok := aggs["buckets"]; ok && v != nil {
 		json.Unmarshal(v, &a.Buckets)
+		json.Unmarshal(v, &a.NamedBuckets)
 	}
 	if v, ok := aggs["meta"]; ok && v != nil {
 		json.Unmarshal(v, &a.Meta)
@@ -1443,6 +1537,63 @@ func (a *AggregationBucketSignificantTerm) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// -- Bucket types for categorize text --
+
+// AggregationBucketCategorizeTextItems is a bucket aggregation returned
+// with a categorize text aggregation.
+type AggregationBucketCategorizeTextItems struct {
+	Aggregations
+
+	Buckets []*AggregationBucketCategorizeTextItem //`json:"buckets"`
+	Meta    map[string]interface{}                 // `json:"meta,omitempty"`
+}
+
+// UnmarshalJSON decodes JSON data and initializes an AggregationBucketCategorizeTextItems structure.
+func (a *AggregationBucketCategorizeTextItems) UnmarshalJSON(data []byte) error {
+	var aggs map[string]json.RawMessage
+	if err := json.Unmarshal(data, &aggs); err != nil {
+		return err
+	}
+	if v, ok := aggs["buckets"]; ok && v != nil {
+		json.Unmarshal(v, &a.Buckets)
+	}
+	if v, ok := aggs["meta"]; ok && v != nil {
+		json.Unmarshal(v, &a.Meta)
+	}
+	a.Aggregations = aggs
+	return nil
+}
+
+// AggregationBucketCategorizeTextItem is a single bucket of an
+// AggregationBucketCategorizeTextItems structure. Key is the category
+// pattern, i.e. the tokens shared by all messages in the category.
+type AggregationBucketCategorizeTextItem struct {
+	Aggregations
+
+	Key               string //`json:"key"`
+	DocCount          int64  //`json:"doc_count"`
+	MaxMatchingLength *int   //`json:"max_matching_length,omitempty"`
+}
+
+// UnmarshalJSON decodes JSON data and initializes an AggregationBucketCategorizeTextItem structure.
+func (a *AggregationBucketCategorizeTextItem) UnmarshalJSON(data []byte) error {
+	var aggs map[string]json.RawMessage
+	if err := json.Unmarshal(data, &aggs); err != nil {
+		return err
+	}
+	if v, ok := aggs["key"]; ok && v != nil {
+		json.Unmarshal(v, &a.Key)
+	}
+	if v, ok := aggs["doc_count"]; ok && v != nil {
+		json.Unmarshal(v, &a.DocCount)
+	}
+	if v, ok := aggs["max_matching_length"]; ok && v != nil {
+		json.Unmarshal(v, &a.MaxMatchingLength)
+	}
+	a.Aggregations = aggs
+	return nil
+}
+
 // -- Bucket filters --
 
 // AggregationBucketFilters is a multi-bucket aggregation that is returned
@@ -1579,6 +1730,76 @@ func (a *AggregationBucketHistogramItem) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// AggregationBucketVariableWidthHistogramItems is a bucket aggregation that is returned
+// with a variable width histogram aggregation.
+type AggregationBucketVariableWidthHistogramItems struct {
+	Aggregations
+
+	Buckets []*AggregationBucketVariableWidthHistogramItem //`json:"buckets"`
+	Meta    map[string]interface{}                         // `json:"meta,omitempty"`
+}
+
+// UnmarshalJSON decodes JSON data and initializes an AggregationBucketVariableWidthHistogramItems structure.
+func (a *AggregationBucketVariableWidthHistogramItems) UnmarshalJSON(data []byte) error {
+	var aggs map[string]json.RawMessage
+	if err := json.Unmarshal(data, &aggs); err != nil {
+		return err
+	}
+	if v, ok := aggs["buckets"]; ok && v != nil {
+		json.Unmarshal(v, &a.Buckets)
+	}
+	if v, ok := aggs["meta"]; ok && v != nil {
+		json.Unmarshal(v, &a.Meta)
+	}
+	a.Aggregations = aggs
+	return nil
+}
+
+// AggregationBucketVariableWidthHistogramItem is a single bucket of an
+// AggregationBucketVariableWidthHistogramItems structure.
+type AggregationBucketVariableWidthHistogramItem struct {
+	Aggregations
+
+	Min         float64 //`json:"min"`
+	MinAsString *string //`json:"min_as_string"`
+	Key         float64 //`json:"key"`
+	KeyAsString *string //`json:"key_as_string"`
+	Max         float64 //`json:"max"`
+	MaxAsString *string //`json:"max_as_string"`
+	DocCount    int64   //`json:"doc_count"`
+}
+
+// UnmarshalJSON decodes JSON data and initializes an AggregationBucketVariableWidthHistogramItem structure.
+func (a *AggregationBucketVariableWidthHistogramItem) UnmarshalJSON(data []byte) error {
+	var aggs map[string]json.RawMessage
+	if err := json.Unmarshal(data, &aggs); err != nil {
+		return err
+	}
+	if v, ok := aggs["min"]; ok && v != nil {
+		json.Unmarshal(v, &a.Min)
+	}
+	if v, ok := aggs["min_as_string"]; ok && v != nil {
+		json.Unmarshal(v, &a.MinAsString)
+	}
+	if v, ok := aggs["key"]; ok && v != nil {
+		json.Unmarshal(v, &a.Key)
+	}
+	if v, ok := aggs["key_as_string"]; ok && v != nil {
+		json.Unmarshal(v, &a.KeyAsString)
+	}
+	if v, ok := aggs["max"]; ok && v != nil {
+		json.Unmarshal(v, &a.Max)
+	}
+	if v, ok := aggs["max_as_string"]; ok && v != nil {
+		json.Unmarshal(v, &a.MaxAsString)
+	}
+	if v, ok := aggs["doc_count"]; ok && v != nil {
+		json.Unmarshal(v, &a.DocCount)
+	}
+	a.Aggregations = aggs
+	return nil
+}
+
 // -- Pipeline simple value --
 
 // AggregationPipelineSimpleValue is a simple value, returned e.g. by a
@@ -1702,9 +1923,26 @@ type AggregationPipelineStatsMetric struct {
 	Sum           *float64 // `json:"sum"`
 	SumAsString   string   // `json:"sum_as_string"`
 
+	// The following fields are only returned by extended_stats_bucket.
+	SumOfSquares       *float64                       // `json:"sum_of_squares,omitempty"`
+	Variance           *float64                       // `json:"variance,omitempty"`
+	StdDeviation       *float64                       // `json:"std_deviation,omitempty"`
+	StdDeviationBounds *AggregationStdDeviationBounds // `json:"std_deviation_bounds,omitempty"`
+
 	Meta map[string]interface{} // `json:"meta,omitempty"`
 }
 
+// AggregationStdDeviationBounds specifies the bounds of
+// average plus/minus sigma standard deviations.
+type AggregationStdDeviationBounds struct {
+	Upper           *float64 `json:"upper,omitempty"`
+	Lower           *float64 `json:"lower,omitempty"`
+	UpperPopulation *float64 `json:"upper_population,omitempty"`
+	LowerPopulation *float64 `json:"lower_population,omitempty"`
+	UpperSampling   *float64 `json:"upper_sampling,omitempty"`
+	LowerSampling   *float64 `json:"lower_sampling,omitempty"`
+}
+
 // UnmarshalJSON decodes JSON data and initializes an AggregationPipelineStatsMetric structure.
 func (a *AggregationPipelineStatsMetric) UnmarshalJSON(data []byte) error {
 	var aggs map[string]json.RawMessage
@@ -1741,6 +1979,18 @@ func (a *AggregationPipelineStatsMetric) UnmarshalJSON(data []byte) error {
 	if v, ok := aggs["sum_as_string"]; ok && v != nil {
 		json.Unmarshal(v, &a.SumAsString)
 	}
+	if v, ok := aggs["sum_of_squares"]; ok && v != nil {
+		json.Unmarshal(v, &a.SumOfSquares)
+	}
+	if v, ok := aggs["variance"]; ok && v != nil {
+		json.Unmarshal(v, &a.Variance)
+	}
+	if v, ok := aggs["std_deviation"]; ok && v != nil {
+		json.Unmarshal(v, &a.StdDeviation)
+	}
+	if v, ok := aggs["std_deviation_bounds"]; ok && v != nil {
+		json.Unmarshal(v, &a.StdDeviationBounds)
+	}
 	if v, ok := aggs["meta"]; ok && v != nil {
 		json.Unmarshal(v, &a.Meta)
 	}
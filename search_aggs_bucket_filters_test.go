@@ -80,6 +80,25 @@ func TestFiltersAggregationWithOtherBuckets(t *testing.T) {
 	}
 }
 
+func TestFiltersAggregationWithUnnamedFiltersAndOtherBucket(t *testing.T) {
+	agg := NewFiltersAggregation().
+		Filters(NewTermQuery("level", "error"), NewTermQuery("level", "warn")).
+		OtherBucket(true)
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"filters":{"filters":[{"term":{"level":"error"}},{"term":{"level":"warn"}}],"other_bucket":true}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestFiltersAggregationWithSubAggregation(t *testing.T) {
 	avgPriceAgg := NewAvgAggregation().Field("price")
 	f1 := NewRangeQuery("stock").Gt(0)
@@ -18,6 +18,7 @@ type GeoDistanceAggregation struct {
 	distanceType    string
 	point           string
 	ranges          []geoDistAggRange
+	keyed           *bool
 	subAggregations map[string]Aggregation
 	meta            map[string]interface{}
 }
@@ -55,6 +56,13 @@ func (a *GeoDistanceAggregation) Point(latLon string) *GeoDistanceAggregation {
 	return a
 }
 
+// Keyed returns the buckets as a map keyed by the range key, e.g.
+// "*-100.0", instead of an array. Use NamedBuckets to read them.
+func (a *GeoDistanceAggregation) Keyed(keyed bool) *GeoDistanceAggregation {
+	a.keyed = &keyed
+	return a
+}
+
 func (a *GeoDistanceAggregation) SubAggregation(name string, subAggregation Aggregation) *GeoDistanceAggregation {
 	a.subAggregations[name] = subAggregation
 	return a
@@ -141,6 +149,9 @@ func (a *GeoDistanceAggregation) Source() (interface{}, error) {
 	if a.point != "" {
 		opts["origin"] = a.point
 	}
+	if a.keyed != nil {
+		opts["keyed"] = *a.keyed
+	}
 
 	var ranges []interface{}
 	for _, ent := range a.ranges {
@@ -91,3 +91,22 @@ func TestGeoDistanceAggregationWithMetaData(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestGeoDistanceAggregationKeyed(t *testing.T) {
+	agg := NewGeoDistanceAggregation().Field("location").Point("52.3760, 4.894").Keyed(true)
+	agg = agg.AddRange(nil, 100)
+	agg = agg.AddRangeWithKey("near", 100, 300)
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"geo_distance":{"field":"location","keyed":true,"origin":"52.3760, 4.894","ranges":[{"to":100},{"from":100,"key":"near","to":300}]}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
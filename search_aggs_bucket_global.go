@@ -69,3 +69,24 @@ func (a *GlobalAggregation) Source() (interface{}, error) {
 
 	return source, nil
 }
+
+// FacetFilterAggregationName is the name of the filter sub-aggregation
+// created by NewFacetAggregation.
+const FacetFilterAggregationName = "filtered"
+
+// NewFacetAggregation returns a GlobalAggregation with a filter
+// sub-aggregation (named FacetFilterAggregationName) that restricts
+// the documents to baseQuery. Facets added to the returned
+// FilterAggregation are computed independently of the main query and
+// post filter of the search, which is what faceted navigation needs:
+//
+//	global, filter := NewFacetAggregation(NewTermQuery("category", "shoes"))
+//	filter.SubAggregation("brands", NewTermsAggregation().Field("brand"))
+//	searchSource.Aggregation("facets", global)
+//
+// Read the results via Global(name) and then Filter(FacetFilterAggregationName).
+func NewFacetAggregation(baseQuery Query) (*GlobalAggregation, *FilterAggregation) {
+	filter := NewFilterAggregation().Filter(baseQuery)
+	global := NewGlobalAggregation().SubAggregation(FacetFilterAggregationName, filter)
+	return global, filter
+}
@@ -42,3 +42,21 @@ func TestGlobalAggregationWithMetaData(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestFacetAggregation(t *testing.T) {
+	agg, filter := NewFacetAggregation(NewTermQuery("category", "shoes"))
+	filter.SubAggregation("brands", NewTermsAggregation().Field("brand"))
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"aggregations":{"filtered":{"aggregations":{"brands":{"terms":{"field":"brand"}}},"filter":{"term":{"category":"shoes"}}}},"global":{}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
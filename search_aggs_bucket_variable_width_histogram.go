@@ -0,0 +1,146 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// VariableWidthHistogramAggregation is a multi-bucket aggregation similar to
+// the histogram. However, the width of each bucket is not specified. Rather,
+// a target number of buckets is provided and bucket intervals are dynamically
+// determined based on the document distribution.
+// See: https://www.elastic.co/guide/en/elasticsearch/reference/7.17/search-aggregations-bucket-variablewidthhistogram-aggregation.html
+type VariableWidthHistogramAggregation struct {
+	field           string
+	script          *Script
+	missing         interface{}
+	subAggregations map[string]Aggregation
+	meta            map[string]interface{}
+
+	buckets       *int
+	shardSize     *int
+	initialBuffer *int
+}
+
+// NewVariableWidthHistogramAggregation creates a new VariableWidthHistogramAggregation.
+func NewVariableWidthHistogramAggregation() *VariableWidthHistogramAggregation {
+	return &VariableWidthHistogramAggregation{
+		subAggregations: make(map[string]Aggregation),
+	}
+}
+
+// Field on which the aggregation is processed.
+func (a *VariableWidthHistogramAggregation) Field(field string) *VariableWidthHistogramAggregation {
+	a.field = field
+	return a
+}
+
+// Script on which the aggregation is processed.
+func (a *VariableWidthHistogramAggregation) Script(script *Script) *VariableWidthHistogramAggregation {
+	a.script = script
+	return a
+}
+
+// Missing configures the value to use when documents miss a value.
+func (a *VariableWidthHistogramAggregation) Missing(missing interface{}) *VariableWidthHistogramAggregation {
+	a.missing = missing
+	return a
+}
+
+// SubAggregation adds a sub-aggregation to this aggregation.
+func (a *VariableWidthHistogramAggregation) SubAggregation(name string, subAggregation Aggregation) *VariableWidthHistogramAggregation {
+	a.subAggregations[name] = subAggregation
+	return a
+}
+
+// Meta sets the meta data to be included in the aggregation response.
+func (a *VariableWidthHistogramAggregation) Meta(metaData map[string]interface{}) *VariableWidthHistogramAggregation {
+	a.meta = metaData
+	return a
+}
+
+// Buckets is the target number of buckets (10 by default).
+func (a *VariableWidthHistogramAggregation) Buckets(buckets int) *VariableWidthHistogramAggregation {
+	a.buckets = &buckets
+	return a
+}
+
+// ShardSize is the number of buckets that the coordinating node will
+// request from each shard (defaults to buckets * 50).
+func (a *VariableWidthHistogramAggregation) ShardSize(shardSize int) *VariableWidthHistogramAggregation {
+	a.shardSize = &shardSize
+	return a
+}
+
+// InitialBuffer specifies the number of individual documents that will be
+// stored in memory on a shard before the initial bucketing algorithm is run
+// (defaults to min(10 * shard_size, 50000)).
+func (a *VariableWidthHistogramAggregation) InitialBuffer(initialBuffer int) *VariableWidthHistogramAggregation {
+	a.initialBuffer = &initialBuffer
+	return a
+}
+
+// Source returns the a JSON-serializable interface.
+func (a *VariableWidthHistogramAggregation) Source() (interface{}, error) {
+	// Example:
+	// {
+	//     "aggs" : {
+	//         "prices" : {
+	//             "variable_width_histogram" : {
+	//                 "field" : "price",
+	//                 "buckets" : 2
+	//             }
+	//         }
+	//     }
+	// }
+	//
+	// This method returns only the { "variable_width_histogram" : { ... } } part.
+
+	source := make(map[string]interface{})
+	opts := make(map[string]interface{})
+	source["variable_width_histogram"] = opts
+
+	// ValuesSourceAggregationBuilder
+	if a.field != "" {
+		opts["field"] = a.field
+	}
+	if a.script != nil {
+		src, err := a.script.Source()
+		if err != nil {
+			return nil, err
+		}
+		opts["script"] = src
+	}
+	if a.missing != nil {
+		opts["missing"] = a.missing
+	}
+
+	if a.buckets != nil {
+		opts["buckets"] = *a.buckets
+	}
+	if a.shardSize != nil {
+		opts["shard_size"] = *a.shardSize
+	}
+	if a.initialBuffer != nil {
+		opts["initial_buffer"] = *a.initialBuffer
+	}
+
+	// AggregationBuilder (SubAggregations)
+	if len(a.subAggregations) > 0 {
+		aggsMap := make(map[string]interface{})
+		source["aggregations"] = aggsMap
+		for name, aggregate := range a.subAggregations {
+			src, err := aggregate.Source()
+			if err != nil {
+				return nil, err
+			}
+			aggsMap[name] = src
+		}
+	}
+
+	// Add Meta data if available
+	if len(a.meta) > 0 {
+		source["meta"] = a.meta
+	}
+
+	return source, nil
+}
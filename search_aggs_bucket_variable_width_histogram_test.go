@@ -0,0 +1,51 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestVariableWidthHistogramAggregation(t *testing.T) {
+	agg := NewVariableWidthHistogramAggregation().
+		Field("price").
+		Buckets(2)
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"variable_width_histogram":{"buckets":2,"field":"price"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestVariableWidthHistogramAggregationWithSubAggregation(t *testing.T) {
+	agg := NewVariableWidthHistogramAggregation().
+		Field("price").
+		Buckets(5).
+		ShardSize(100).
+		InitialBuffer(500).
+		SubAggregation("avg_rating", NewAvgAggregation().Field("rating"))
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"aggregations":{"avg_rating":{"avg":{"field":"rating"}}},"variable_width_histogram":{"buckets":5,"field":"price","initial_buffer":500,"shard_size":100}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
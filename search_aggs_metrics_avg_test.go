@@ -43,6 +43,23 @@ func TestAvgAggregationWithOptions(t *testing.T) {
 	}
 }
 
+func TestAvgAggregationWithMissingZero(t *testing.T) {
+	agg := NewAvgAggregation().Field("grade").Missing(0)
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"avg":{"field":"grade","missing":0}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestAvgAggregationWithMetaData(t *testing.T) {
 	agg := NewAvgAggregation().Field("grade").Meta(map[string]interface{}{"name": "Oliver"})
 	src, err := agg.Source()
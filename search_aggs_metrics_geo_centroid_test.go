@@ -42,3 +42,21 @@ func TestGeoCentroidAggregationWithMetaData(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestGeoCentroidAggregationAsSubAggregation(t *testing.T) {
+	agg := NewGeoHashGridAggregation().Field("location").Precision(3).
+		SubAggregation("centroid", NewGeoCentroidAggregation().Field("location"))
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"aggregations":{"centroid":{"geo_centroid":{"field":"location"}}},"geohash_grid":{"field":"location","precision":3}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
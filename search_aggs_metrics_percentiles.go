@@ -23,6 +23,7 @@ type PercentilesAggregation struct {
 	compression                    *float64
 	numberOfSignificantValueDigits *int
 	estimator                      string
+	keyed                          *bool
 }
 
 func NewPercentilesAggregation() *PercentilesAggregation {
@@ -69,6 +70,11 @@ func (a *PercentilesAggregation) Percentiles(percentiles ...float64) *Percentile
 	return a
 }
 
+// Percents is an alias for Percentiles.
+func (a *PercentilesAggregation) Percents(percents ...float64) *PercentilesAggregation {
+	return a.Percentiles(percents...)
+}
+
 // Method is the percentiles method, which can be "tdigest" (default) or "hdr".
 func (a *PercentilesAggregation) Method(method string) *PercentilesAggregation {
 	a.method = method
@@ -85,6 +91,31 @@ func (a *PercentilesAggregation) NumberOfSignificantValueDigits(digits int) *Per
 	return a
 }
 
+// Tdigest uses the TDigest algorithm with the given compression.
+// It is a shortcut for Method("tdigest") and Compression(compression).
+func (a *PercentilesAggregation) Tdigest(compression float64) *PercentilesAggregation {
+	a.method = "tdigest"
+	a.compression = &compression
+	return a
+}
+
+// Hdr uses the HDR Histogram algorithm with the given number of significant
+// digits. It is a shortcut for Method("hdr") and
+// NumberOfSignificantValueDigits(digits).
+func (a *PercentilesAggregation) Hdr(numberOfSignificantValueDigits int) *PercentilesAggregation {
+	a.method = "hdr"
+	a.numberOfSignificantValueDigits = &numberOfSignificantValueDigits
+	return a
+}
+
+// Keyed specifies whether to return the percentiles as a hash (default)
+// or as an array of key/value pairs. The latter is decoded into
+// AggregationPercentilesMetric.ValuesList.
+func (a *PercentilesAggregation) Keyed(keyed bool) *PercentilesAggregation {
+	a.keyed = &keyed
+	return a
+}
+
 func (a *PercentilesAggregation) Estimator(estimator string) *PercentilesAggregation {
 	a.estimator = estimator
 	return a
@@ -146,6 +177,9 @@ func (a *PercentilesAggregation) Source() (interface{}, error) {
 	if a.estimator != "" {
 		opts["estimator"] = a.estimator
 	}
+	if a.keyed != nil {
+		opts["keyed"] = *a.keyed
+	}
 
 	// AggregationBuilder (SubAggregations)
 	if len(a.subAggregations) > 0 {
@@ -117,3 +117,45 @@ func TestPercentilesAggregationWithNumberOfSignificantValueDigits(t *testing.T)
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestPercentilesAggregationWithTdigest(t *testing.T) {
+	agg := NewPercentilesAggregation().
+		Field("load_time").
+		Percents(50, 95).
+		Tdigest(100).
+		Keyed(false)
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"percentiles":{"field":"load_time","keyed":false,"percents":[50,95],"tdigest":{"compression":100}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestPercentilesAggregationWithHdr(t *testing.T) {
+	agg := NewPercentilesAggregation().
+		Field("load_time").
+		Percents(95, 99, 99.9).
+		Hdr(3).
+		Keyed(true)
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"percentiles":{"field":"load_time","hdr":{"number_of_significant_value_digits":3},"keyed":true,"percents":[95,99,99.9]}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
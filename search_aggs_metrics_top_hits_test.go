@@ -29,3 +29,23 @@ func TestTopHitsAggregation(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestTopHitsAggregationWithTrackScores(t *testing.T) {
+	agg := NewTopHitsAggregation().
+		Sort("last_activity_date", false).
+		TrackScores(true).
+		Size(3)
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"top_hits":{"size":3,"sort":[{"last_activity_date":{"order":"desc"}}],"track_scores":true}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
@@ -16,6 +16,7 @@ type ValueCountAggregation struct {
 	field           string
 	script          *Script
 	format          string
+	missing         interface{}
 	subAggregations map[string]Aggregation
 	meta            map[string]interface{}
 }
@@ -41,6 +42,11 @@ func (a *ValueCountAggregation) Format(format string) *ValueCountAggregation {
 	return a
 }
 
+func (a *ValueCountAggregation) Missing(missing interface{}) *ValueCountAggregation {
+	a.missing = missing
+	return a
+}
+
 func (a *ValueCountAggregation) SubAggregation(name string, subAggregation Aggregation) *ValueCountAggregation {
 	a.subAggregations[name] = subAggregation
 	return a
@@ -79,6 +85,9 @@ func (a *ValueCountAggregation) Source() (interface{}, error) {
 	if a.format != "" {
 		opts["format"] = a.format
 	}
+	if a.missing != nil {
+		opts["missing"] = a.missing
+	}
 
 	// AggregationBuilder (SubAggregations)
 	if len(a.subAggregations) > 0 {
@@ -44,6 +44,23 @@ func TestValueCountAggregationWithFormat(t *testing.T) {
 	}
 }
 
+func TestValueCountAggregationWithMissing(t *testing.T) {
+	agg := NewValueCountAggregation().Field("grade").Missing(0)
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"value_count":{"field":"grade","missing":0}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestValueCountAggregationWithMetaData(t *testing.T) {
 	agg := NewValueCountAggregation().Field("grade")
 	agg = agg.Meta(map[string]interface{}{"name": "Oliver"})
@@ -27,3 +27,25 @@ func TestBucketSelectorAggregation(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestBucketSelectorAggregationInDateHistogram(t *testing.T) {
+	h := NewDateHistogramAggregation().Field("date").CalendarInterval("month").
+		SubAggregation("total_sales", NewSumAggregation().Field("price")).
+		SubAggregation("sales_bucket_filter", NewBucketSelectorAggregation().
+			AddBucketsPath("totalSales", "total_sales").
+			Script(NewScript("params.totalSales != 0")))
+	builder := NewSearchSource().Size(0).Aggregation("sales_per_month", h)
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"aggregations":{"sales_per_month":{"aggregations":{"sales_bucket_filter":{"bucket_selector":{"buckets_path":{"totalSales":"total_sales"},"script":{"source":"params.totalSales != 0"}}},"total_sales":{"sum":{"field":"price"}}},"date_histogram":{"calendar_interval":"month","field":"date"}}},"size":0}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
@@ -31,3 +31,28 @@ func TestBuckerSortAggregation(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestBucketSortAggregationTopNWithSelector(t *testing.T) {
+	agg := NewTermsAggregation().Field("product").
+		SubAggregation("total_sales", NewSumAggregation().Field("price")).
+		SubAggregation("min_sales", NewBucketSelectorAggregation().
+			BucketsPathsMap(map[string]string{"totalSales": "total_sales"}).
+			Script(NewScript("params.totalSales > 200"))).
+		SubAggregation("top_3", NewBucketSortAggregation().
+			Sort("total_sales", false).
+			Size(3))
+
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"aggregations":{"min_sales":{"bucket_selector":{"buckets_path":{"totalSales":"total_sales"},"script":{"source":"params.totalSales \u003e 200"}}},"top_3":{"bucket_sort":{"size":3,"sort":[{"total_sales":{"order":"desc"}}]}},"total_sales":{"sum":{"field":"price"}}},"terms":{"field":"product"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
@@ -62,3 +62,20 @@ func TestExtendedStatsBucketAggregationWithSigma(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestExtendedStatsBucketAggregationWithSigmaAndGapPolicy(t *testing.T) {
+	agg := NewExtendedStatsBucketAggregation().BucketsPath("the_sum").Sigma(2.5).GapSkip()
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"extended_stats_bucket":{"buckets_path":"the_sum","gap_policy":"skip","sigma":2.5}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
@@ -25,3 +25,37 @@ func TestMaxBucketAggregation(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestMaxBucketAggregationWithFormat(t *testing.T) {
+	agg := NewMaxBucketAggregation().BucketsPath("sales_per_month.sales").Format("0.00")
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"max_bucket":{"buckets_path":"sales_per_month.sales","format":"0.00"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestMaxBucketAggregationWithGapInsertZeros(t *testing.T) {
+	agg := NewMaxBucketAggregation().BucketsPath("the_sum").GapInsertZeros()
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"max_bucket":{"buckets_path":"the_sum","gap_policy":"insert_zeros"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
@@ -10,7 +10,10 @@ package elastic
 // For more details, see
 // https://www.elastic.co/guide/en/elasticsearch/reference/7.0/search-aggregations-pipeline-movavg-aggregation.html
 //
-// Deprecated: The MovAvgAggregation has been deprecated in 6.4.0. Use the more generate MovFnAggregation instead.
+// Deprecated: The moving_avg aggregation has been deprecated in 6.4.0 in
+// favour of moving_fn, and it is removed in Elasticsearch 8.0. Use
+// MovFnAggregation instead, e.g. with a script of
+// "MovingFunctions.unweightedAvg(values)" in place of the simple model.
 type MovAvgAggregation struct {
 	format    string
 	gapPolicy string
@@ -25,7 +28,8 @@ type MovAvgAggregation struct {
 
 // NewMovAvgAggregation creates and initializes a new MovAvgAggregation.
 //
-// Deprecated: The MovAvgAggregation has been deprecated in 6.4.0. Use the more generate MovFnAggregation instead.
+// Deprecated: The moving_avg aggregation has been deprecated in 6.4.0 in
+// favour of moving_fn. Use NewMovFnAggregation instead.
 func NewMovAvgAggregation() *MovAvgAggregation {
 	return &MovAvgAggregation{
 		bucketsPaths: make([]string, 0),
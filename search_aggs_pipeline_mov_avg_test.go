@@ -112,3 +112,41 @@ func TestMovAvgAggregationWithHoltWintersModel(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestMovAvgAggregationWithMultiplicativeHoltWintersModel(t *testing.T) {
+	agg := NewMovAvgAggregation().BucketsPath("the_sum").Window(30).
+		Model(NewHoltWintersMovAvgModel().Alpha(0.5).Beta(0.4).Gamma(0.3).Period(7).SeasonalityType("mult"))
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"moving_avg":{"buckets_path":"the_sum","model":"holt_winters","settings":{"alpha":0.5,"beta":0.4,"gamma":0.3,"period":7,"type":"mult"},"window":30}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestMovAvgAggregationWithEWMAModelInDateHistogram(t *testing.T) {
+	h := NewDateHistogramAggregation().Field("timestamp").CalendarInterval("day")
+	h = h.SubAggregation("the_sum", NewSumAggregation().Field("lemmings"))
+	h = h.SubAggregation("the_movavg", NewMovAvgAggregation().BucketsPath("the_sum").
+		Window(7).Model(NewEWMAMovAvgModel().Alpha(0.3)))
+	src, err := h.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"aggregations":{"the_movavg":{"moving_avg":{"buckets_path":"the_sum","model":"ewma","settings":{"alpha":0.3},"window":7}},"the_sum":{"sum":{"field":"lemmings"}}},"date_histogram":{"calendar_interval":"day","field":"timestamp"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
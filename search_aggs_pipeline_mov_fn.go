@@ -32,8 +32,7 @@ type MovFnAggregation struct {
 }
 
 // NewMovFnAggregation creates and initializes a new MovFnAggregation.
-//
-// Deprecated: The MovFnAggregation has been deprecated in 6.4.0. Use the more generate MovFnAggregation instead.
+// It replaces the deprecated MovAvgAggregation.
 func NewMovFnAggregation(bucketsPath string, script *Script, window int) *MovFnAggregation {
 	return &MovFnAggregation{
 		bucketsPaths: []string{bucketsPath},
@@ -42,3 +42,20 @@ func TestPercentilesBucketAggregationWithPercents(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestPercentilesBucketAggregationWithFormatAndGapPolicy(t *testing.T) {
+	agg := NewPercentilesBucketAggregation().BucketsPath("the_sum").Percents(25, 50, 75).Format("0.0").GapInsertZeros()
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"percentiles_bucket":{"buckets_path":"the_sum","format":"0.0","gap_policy":"insert_zeros","percents":[25,50,75]}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
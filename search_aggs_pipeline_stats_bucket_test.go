@@ -25,3 +25,20 @@ func TestStatsBucketAggregation(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestStatsBucketAggregationWithFormat(t *testing.T) {
+	agg := NewStatsBucketAggregation().BucketsPath("sales_per_month.sales").Format("0.00").GapInsertZeros()
+	src, err := agg.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"stats_bucket":{"buckets_path":"sales_per_month.sales","format":"0.00","gap_policy":"insert_zeros"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
@@ -178,6 +178,38 @@ func TestAggsMetricsCardinality(t *testing.T) {
 	}
 }
 
+func TestAggsMetricsRate(t *testing.T) {
+	s := `{
+	"avg_price": {
+  	"value": 375.0
+  }
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	agg, found := aggs.Rate("avg_price")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if agg == nil {
+		t.Fatalf("expected aggregation != nil; got: %v", agg)
+	}
+	if agg.Value == nil {
+		t.Fatalf("expected aggregation value != nil; got: %v", agg.Value)
+	}
+	if *agg.Value != float64(375) {
+		t.Fatalf("expected aggregation value = %v; got: %v", float64(375), *agg.Value)
+	}
+
+	if _, found := aggs.Rate("no_such_agg"); found {
+		t.Fatalf("expected aggregation not to be found; got: %v", found)
+	}
+}
+
 func TestAggsMetricsStats(t *testing.T) {
 	s := `{
 	"grades_stats": {
@@ -461,6 +493,53 @@ func TestAggsMetricsPercentiles(t *testing.T) {
 	}
 }
 
+func TestAggsMetricsPercentilesNotKeyed(t *testing.T) {
+	s := `{
+  "load_time_outlier": {
+		"values" : [
+			{
+				"key": 1.0,
+				"value": 15
+			},
+			{
+				"key": 99.0,
+				"value": 150,
+				"value_as_string": "150.0"
+			}
+		]
+  }
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	agg, found := aggs.Percentiles("load_time_outlier")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if agg == nil {
+		t.Fatalf("expected aggregation != nil; got: %v", agg)
+	}
+	if agg.Values != nil {
+		t.Fatalf("expected aggregation Values == nil; got: %v", agg.Values)
+	}
+	if len(agg.ValuesList) != 2 {
+		t.Fatalf("expected %d aggregation ValuesList; got: %d", 2, len(agg.ValuesList))
+	}
+	if agg.ValuesList[0].Key != 1 || agg.ValuesList[0].Value != 15 {
+		t.Errorf("expected aggregation value 1.0 = 15; got: %v = %v", agg.ValuesList[0].Key, agg.ValuesList[0].Value)
+	}
+	if agg.ValuesList[1].Key != 99 || agg.ValuesList[1].Value != 150 {
+		t.Errorf("expected aggregation value 99.0 = 150; got: %v = %v", agg.ValuesList[1].Key, agg.ValuesList[1].Value)
+	}
+	if want, have := "150.0", agg.ValuesList[1].ValueAsString; want != have {
+		t.Errorf("expected value_as_string = %q; got: %q", want, have)
+	}
+}
+
 func TestAggsMetricsPercentileRanks(t *testing.T) {
 	s := `{
   "load_time_outlier": {
@@ -1381,6 +1460,60 @@ func TestAggsBucketRange(t *testing.T) {
 	}
 }
 
+func TestAggsBucketRangeWithSubAggregation(t *testing.T) {
+	s := `{
+	"price_ranges" : {
+		"buckets": [
+			{
+				"key": "*-100.0",
+				"to": 100.0,
+				"doc_count": 2,
+				"avg_price": {
+					"value": 75.0
+				}
+			},
+			{
+				"key": "100.0-*",
+				"from": 100.0,
+				"doc_count": 1,
+				"avg_price": {
+					"value": 150.0
+				}
+			}
+		]
+	}
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	agg, found := aggs.Range("price_ranges")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if len(agg.Buckets) != 2 {
+		t.Fatalf("expected %d bucket entries; got: %d", 2, len(agg.Buckets))
+	}
+	for i, want := range []float64{75, 150} {
+		avg, found := agg.Buckets[i].Avg("avg_price")
+		if !found {
+			t.Fatalf("#%d: expected sub-aggregation to be found; got: %v", i, found)
+		}
+		if avg.Value == nil {
+			t.Fatalf("#%d: expected sub-aggregation value != nil; got: %v", i, avg.Value)
+		}
+		if *avg.Value != want {
+			t.Errorf("#%d: expected sub-aggregation value = %v; got: %v", i, want, *avg.Value)
+		}
+	}
+	if _, found := agg.Buckets[0].Avg("missing"); found {
+		t.Errorf("expected sub-aggregation %q not to be found", "missing")
+	}
+}
+
 func TestAggsBucketDateRange(t *testing.T) {
 	s := `{
 	"range": {
@@ -1587,6 +1720,78 @@ func TestAggsBucketHistogram(t *testing.T) {
 	}
 }
 
+func TestAggsBucketVariableWidthHistogram(t *testing.T) {
+	s := `{
+	"prices" : {
+		"buckets": [
+			{
+				"min": 10.0,
+				"key": 30.0,
+				"max": 50.0,
+				"doc_count": 2
+			},
+			{
+				"min": 150.0,
+				"key": 185.0,
+				"max": 200.0,
+				"doc_count": 5,
+				"avg_rating": {
+					"value": 4.5
+				}
+			}
+		]
+	}
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	agg, found := aggs.VariableWidthHistogram("prices")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if agg == nil {
+		t.Fatalf("expected aggregation != nil; got: %v", agg)
+	}
+	if len(agg.Buckets) != 2 {
+		t.Fatalf("expected %d buckets; got: %d", 2, len(agg.Buckets))
+	}
+	if agg.Buckets[0].Min != 10 {
+		t.Errorf("expected min = %v; got: %v", 10, agg.Buckets[0].Min)
+	}
+	if agg.Buckets[0].Key != 30 {
+		t.Errorf("expected key = %v; got: %v", 30, agg.Buckets[0].Key)
+	}
+	if agg.Buckets[0].Max != 50 {
+		t.Errorf("expected max = %v; got: %v", 50, agg.Buckets[0].Max)
+	}
+	if agg.Buckets[0].DocCount != 2 {
+		t.Errorf("expected doc count = %d; got: %d", 2, agg.Buckets[0].DocCount)
+	}
+	if agg.Buckets[1].Min != 150 {
+		t.Errorf("expected min = %v; got: %v", 150, agg.Buckets[1].Min)
+	}
+	if agg.Buckets[1].Key != 185 {
+		t.Errorf("expected key = %v; got: %v", 185, agg.Buckets[1].Key)
+	}
+	if agg.Buckets[1].Max != 200 {
+		t.Errorf("expected max = %v; got: %v", 200, agg.Buckets[1].Max)
+	}
+	if agg.Buckets[1].DocCount != 5 {
+		t.Errorf("expected doc count = %d; got: %d", 5, agg.Buckets[1].DocCount)
+	}
+	avg, found := agg.Buckets[1].Avg("avg_rating")
+	if !found {
+		t.Fatalf("expected sub aggregation to be found; got: %v", found)
+	}
+	if avg.Value == nil || *avg.Value != 4.5 {
+		t.Errorf("expected avg value = %v; got: %v", 4.5, avg.Value)
+	}
+}
+
 func TestAggsBucketDateHistogram(t *testing.T) {
 	s := `{
 	"articles_over_time": {
@@ -1679,6 +1884,9 @@ func TestAggsMetricsGeoBounds(t *testing.T) {
 	if agg == nil {
 		t.Fatalf("expected aggregation != nil; got: %v", agg)
 	}
+	if agg.Bounds == nil {
+		t.Fatalf("expected Bounds != nil; got: %v", agg.Bounds)
+	}
 	if agg.Bounds.TopLeft.Latitude != float64(80.45) {
 		t.Fatalf("expected Bounds.TopLeft.Latitude != %v; got: %v", float64(80.45), agg.Bounds.TopLeft.Latitude)
 	}
@@ -1693,6 +1901,89 @@ func TestAggsMetricsGeoBounds(t *testing.T) {
 	}
 }
 
+func TestAggsMetricsGeoBoundsEmpty(t *testing.T) {
+	s := `{
+  "viewport": {}
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	agg, found := aggs.GeoBounds("viewport")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if agg == nil {
+		t.Fatalf("expected aggregation != nil; got: %v", agg)
+	}
+	if agg.Bounds != nil {
+		t.Fatalf("expected Bounds == nil; got: %+v", agg.Bounds)
+	}
+}
+
+func TestAggsBucketCategorizeText(t *testing.T) {
+	s := `{
+  "categories": {
+    "buckets": [
+      {
+        "doc_count": 3,
+        "key": "Node shutting down",
+        "max_matching_length": 49,
+        "hosts": {
+          "value": 2
+        }
+      },
+      {
+        "doc_count": 1,
+        "key": "Node starting up"
+      }
+    ]
+  }
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	agg, found := aggs.CategorizeText("categories")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if agg == nil {
+		t.Fatalf("expected aggregation != nil; got: %v", agg)
+	}
+	if len(agg.Buckets) != 2 {
+		t.Fatalf("expected %d buckets; got: %d", 2, len(agg.Buckets))
+	}
+	if agg.Buckets[0].Key != "Node shutting down" {
+		t.Errorf("expected key %q; got: %q", "Node shutting down", agg.Buckets[0].Key)
+	}
+	if agg.Buckets[0].DocCount != 3 {
+		t.Errorf("expected doc count %d; got: %d", 3, agg.Buckets[0].DocCount)
+	}
+	if agg.Buckets[0].MaxMatchingLength == nil || *agg.Buckets[0].MaxMatchingLength != 49 {
+		t.Errorf("expected max matching length %d; got: %v", 49, agg.Buckets[0].MaxMatchingLength)
+	}
+	hosts, found := agg.Buckets[0].Cardinality("hosts")
+	if !found {
+		t.Fatalf("expected sub aggregation to be found; got: %v", found)
+	}
+	if hosts.Value == nil || *hosts.Value != 2 {
+		t.Errorf("expected sub aggregation value %v; got: %v", 2, hosts.Value)
+	}
+	if agg.Buckets[1].Key != "Node starting up" {
+		t.Errorf("expected key %q; got: %q", "Node starting up", agg.Buckets[1].Key)
+	}
+	if agg.Buckets[1].MaxMatchingLength != nil {
+		t.Errorf("expected no max matching length; got: %v", *agg.Buckets[1].MaxMatchingLength)
+	}
+}
+
 func TestAggsBucketGeoHash(t *testing.T) {
 	s := `{
 	"myLarge-GrainGeoHashGrid": {
@@ -1912,6 +2203,64 @@ func TestAggsBucketGeoDistance(t *testing.T) {
 	}
 }
 
+func TestAggsBucketGeoDistanceKeyed(t *testing.T) {
+	s := `{
+	"rings" : {
+		"buckets": {
+			"*-100.0": {
+				"to": 100.0,
+				"doc_count": 3
+			},
+			"100.0-300.0": {
+				"from": 100.0,
+				"to": 300.0,
+				"doc_count": 1
+			}
+		}
+	}
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	agg, found := aggs.GeoDistance("rings")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if agg.Buckets != nil {
+		t.Errorf("expected aggregation buckets == nil; got: %v", agg.Buckets)
+	}
+	if len(agg.NamedBuckets) != 2 {
+		t.Fatalf("expected %d named bucket entries; got: %d", 2, len(agg.NamedBuckets))
+	}
+	bucket, found := agg.NamedBuckets["*-100.0"]
+	if !found {
+		t.Fatalf("expected bucket %q to be found", "*-100.0")
+	}
+	if bucket.From != nil {
+		t.Errorf("expected From = %v; got: %v", nil, bucket.From)
+	}
+	if bucket.To == nil || *bucket.To != 100 {
+		t.Errorf("expected To = %v; got: %v", 100, bucket.To)
+	}
+	if bucket.DocCount != 3 {
+		t.Errorf("expected DocCount = %d; got: %d", 3, bucket.DocCount)
+	}
+	bucket, found = agg.NamedBuckets["100.0-300.0"]
+	if !found {
+		t.Fatalf("expected bucket %q to be found", "100.0-300.0")
+	}
+	if bucket.From == nil || *bucket.From != 100 {
+		t.Errorf("expected From = %v; got: %v", 100, bucket.From)
+	}
+	if bucket.DocCount != 1 {
+		t.Errorf("expected DocCount = %d; got: %d", 1, bucket.DocCount)
+	}
+}
+
 func TestAggsSubAggregates(t *testing.T) {
 	rs := `{
 	"users" : {
@@ -2090,6 +2439,48 @@ func TestAggsPipelineMaxBucket(t *testing.T) {
 	}
 }
 
+func TestAggsPipelineMaxBucketWithMultipleKeys(t *testing.T) {
+	s := `{
+	"max_monthly_sales" : {
+		"keys": ["2015/01/01 00:00:00", "2015/03/01 00:00:00"],
+		"value" : 550,
+		"value_as_string": "550.00"
+	}
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	agg, found := aggs.MaxBucket("max_monthly_sales")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if agg == nil {
+		t.Fatalf("expected aggregation != nil; got: %v", agg)
+	}
+	if len(agg.Keys) != 2 {
+		t.Fatalf("expected 2 keys; got: %d", len(agg.Keys))
+	}
+	if got, want := agg.Keys[0], "2015/01/01 00:00:00"; got != want {
+		t.Fatalf("expected key %q; got: %v (%T)", want, got, got)
+	}
+	if got, want := agg.Keys[1], "2015/03/01 00:00:00"; got != want {
+		t.Fatalf("expected key %q; got: %v (%T)", want, got, got)
+	}
+	if agg.Value == nil {
+		t.Fatalf("expected aggregation value != nil; got: %v", agg.Value)
+	}
+	if *agg.Value != float64(550) {
+		t.Fatalf("expected aggregation value = %v; got: %v", float64(550), *agg.Value)
+	}
+	if got, want := agg.ValueAsString, "550.00"; got != want {
+		t.Fatalf("expected value_as_string %q; got: %q", want, got)
+	}
+}
+
 func TestAggsPipelineMinBucket(t *testing.T) {
 	s := `{
 	"min_monthly_sales" : {
@@ -2125,6 +2516,48 @@ func TestAggsPipelineMinBucket(t *testing.T) {
 	}
 }
 
+func TestAggsPipelineMinBucketWithMultipleKeys(t *testing.T) {
+	s := `{
+	"min_monthly_sales" : {
+		"keys": ["2015/02/01 00:00:00", "2015/04/01 00:00:00"],
+		"value" : 60,
+		"value_as_string": "60.00"
+	}
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	agg, found := aggs.MinBucket("min_monthly_sales")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if agg == nil {
+		t.Fatalf("expected aggregation != nil; got: %v", agg)
+	}
+	if len(agg.Keys) != 2 {
+		t.Fatalf("expected 2 keys; got: %d", len(agg.Keys))
+	}
+	if got, want := agg.Keys[0], "2015/02/01 00:00:00"; got != want {
+		t.Fatalf("expected key %q; got: %v (%T)", want, got, got)
+	}
+	if got, want := agg.Keys[1], "2015/04/01 00:00:00"; got != want {
+		t.Fatalf("expected key %q; got: %v (%T)", want, got, got)
+	}
+	if agg.Value == nil {
+		t.Fatalf("expected aggregation value != nil; got: %v", agg.Value)
+	}
+	if *agg.Value != float64(60) {
+		t.Fatalf("expected aggregation value = %v; got: %v", float64(60), *agg.Value)
+	}
+	if got, want := agg.ValueAsString, "60.00"; got != want {
+		t.Fatalf("expected value_as_string %q; got: %q", want, got)
+	}
+}
+
 func TestAggsPipelineMovAvg(t *testing.T) {
 	s := `{
 	"the_movavg" : {
@@ -2262,6 +2695,93 @@ func TestAggsPipelineStatsBucket(t *testing.T) {
 	}
 }
 
+func TestAggsPipelineExtendedStatsBucket(t *testing.T) {
+	s := `{
+	"stats_monthly_sales": {
+	 "count": 3,
+	 "min": 60.0,
+	 "max": 550.0,
+	 "avg": 328.3333333333333,
+	 "sum": 985.0,
+	 "sum_of_squares": 446725.0,
+	 "variance": 41105.55555555556,
+	 "std_deviation": 202.74505063146563,
+	 "std_deviation_bounds": {
+	   "upper": 733.8234345962646,
+	   "lower": -77.15676792959795
+	 }
+  }
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	agg, found := aggs.ExtendedStatsBucket("stats_monthly_sales")
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if agg == nil {
+		t.Fatalf("expected aggregation != nil; got: %v", agg)
+	}
+	if agg.Count != 3 {
+		t.Fatalf("expected aggregation count = %v; got: %v", 3, agg.Count)
+	}
+	if agg.Min == nil {
+		t.Fatalf("expected aggregation min != nil; got: %v", agg.Min)
+	}
+	if *agg.Min != float64(60.0) {
+		t.Fatalf("expected aggregation min = %v; got: %v", float64(60.0), *agg.Min)
+	}
+	if agg.Max == nil {
+		t.Fatalf("expected aggregation max != nil; got: %v", agg.Max)
+	}
+	if *agg.Max != float64(550.0) {
+		t.Fatalf("expected aggregation max = %v; got: %v", float64(550.0), *agg.Max)
+	}
+	if agg.Avg == nil {
+		t.Fatalf("expected aggregation avg != nil; got: %v", agg.Avg)
+	}
+	if *agg.Avg != float64(328.3333333333333) {
+		t.Fatalf("expected aggregation average = %v; got: %v", float64(328.3333333333333), *agg.Avg)
+	}
+	if agg.Sum == nil {
+		t.Fatalf("expected aggregation sum != nil; got: %v", agg.Sum)
+	}
+	if *agg.Sum != float64(985.0) {
+		t.Fatalf("expected aggregation sum = %v; got: %v", float64(985.0), *agg.Sum)
+	}
+	if agg.SumOfSquares == nil {
+		t.Fatalf("expected aggregation sum_of_squares != nil; got: %v", agg.SumOfSquares)
+	}
+	if *agg.SumOfSquares != float64(446725.0) {
+		t.Fatalf("expected aggregation sum_of_squares = %v; got: %v", float64(446725.0), *agg.SumOfSquares)
+	}
+	if agg.Variance == nil {
+		t.Fatalf("expected aggregation variance != nil; got: %v", agg.Variance)
+	}
+	if *agg.Variance != float64(41105.55555555556) {
+		t.Fatalf("expected aggregation variance = %v; got: %v", float64(41105.55555555556), *agg.Variance)
+	}
+	if agg.StdDeviation == nil {
+		t.Fatalf("expected aggregation std_deviation != nil; got: %v", agg.StdDeviation)
+	}
+	if *agg.StdDeviation != float64(202.74505063146563) {
+		t.Fatalf("expected aggregation std_deviation = %v; got: %v", float64(202.74505063146563), *agg.StdDeviation)
+	}
+	if agg.StdDeviationBounds == nil {
+		t.Fatalf("expected aggregation std_deviation_bounds != nil; got: %v", agg.StdDeviationBounds)
+	}
+	if agg.StdDeviationBounds.Upper == nil || *agg.StdDeviationBounds.Upper != float64(733.8234345962646) {
+		t.Fatalf("expected aggregation std_deviation_bounds.upper = %v; got: %v", float64(733.8234345962646), agg.StdDeviationBounds.Upper)
+	}
+	if agg.StdDeviationBounds.Lower == nil || *agg.StdDeviationBounds.Lower != float64(-77.15676792959795) {
+		t.Fatalf("expected aggregation std_deviation_bounds.lower = %v; got: %v", float64(-77.15676792959795), agg.StdDeviationBounds.Lower)
+	}
+}
+
 func TestAggsPipelineCumulativeSum(t *testing.T) {
 	s := `{
 	"cumulative_sales" : {
@@ -2644,3 +3164,60 @@ func TestAggsScriptedMetric(t *testing.T) {
 		t.Fatalf("expected aggregation value is map[string]interface{}; got: %+v", agg.Value)
 	}
 }
+
+func TestAggsUnmarshalAgg(t *testing.T) {
+	s := `{
+	"my_plugin_agg" : {
+		"score" : 0.75,
+		"labels" : ["a", "b"],
+		"meta" : {
+			"source" : "plugin"
+		}
+	}
+}`
+
+	aggs := new(Aggregations)
+	err := json.Unmarshal([]byte(s), &aggs)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+
+	var custom struct {
+		Score  float64           `json:"score"`
+		Labels []string          `json:"labels"`
+		Meta   map[string]string `json:"meta"`
+	}
+	found, err := aggs.UnmarshalAgg("my_plugin_agg", &custom)
+	if err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if want, have := 0.75, custom.Score; want != have {
+		t.Fatalf("expected score = %v; got: %v", want, have)
+	}
+	if want, have := []string{"a", "b"}, custom.Labels; !reflect.DeepEqual(want, have) {
+		t.Fatalf("expected labels = %v; got: %v", want, have)
+	}
+	if want, have := "plugin", custom.Meta["source"]; want != have {
+		t.Fatalf("expected meta.source = %q; got: %q", want, have)
+	}
+
+	found, err = aggs.UnmarshalAgg("no_such_agg", &custom)
+	if err != nil {
+		t.Fatalf("expected no error; got: %v", err)
+	}
+	if found {
+		t.Fatalf("expected aggregation not to be found; got: %v", found)
+	}
+
+	var mismatch []string
+	found, err = aggs.UnmarshalAgg("my_plugin_agg", &mismatch)
+	if !found {
+		t.Fatalf("expected aggregation to be found; got: %v", found)
+	}
+	if err == nil {
+		t.Fatal("expected error decoding into mismatching type")
+	}
+}
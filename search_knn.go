@@ -0,0 +1,115 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// KnnQuery finds the k nearest vectors to a query vector, as measured
+// by a similarity metric, in a dense_vector field.
+//
+// For more details, see:
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/knn-search.html
+type KnnQuery struct {
+	field         string
+	queryVector   []float32
+	k             int
+	numCandidates int
+	filter        []Query
+	boost         *float64
+	similarity    *float64
+}
+
+// NewKnnQuery creates and initializes a new KnnQuery.
+func NewKnnQuery(field string, queryVector []float32, k, numCandidates int) *KnnQuery {
+	return &KnnQuery{
+		field:         field,
+		queryVector:   queryVector,
+		k:             k,
+		numCandidates: numCandidates,
+	}
+}
+
+// Field is the name of the dense_vector field to search against.
+func (q *KnnQuery) Field(field string) *KnnQuery {
+	q.field = field
+	return q
+}
+
+// QueryVector is the vector to search for. It must have the same number
+// of dimensions as the vector field.
+func (q *KnnQuery) QueryVector(queryVector ...float32) *KnnQuery {
+	q.queryVector = queryVector
+	return q
+}
+
+// K is the number of nearest neighbors to return as top hits.
+func (q *KnnQuery) K(k int) *KnnQuery {
+	q.k = k
+	return q
+}
+
+// NumCandidates is the number of nearest neighbor candidates to consider
+// per shard.
+func (q *KnnQuery) NumCandidates(numCandidates int) *KnnQuery {
+	q.numCandidates = numCandidates
+	return q
+}
+
+// Filter adds queries that restrict the documents that can match.
+func (q *KnnQuery) Filter(filters ...Query) *KnnQuery {
+	q.filter = append(q.filter, filters...)
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *KnnQuery) Boost(boost float64) *KnnQuery {
+	q.boost = &boost
+	return q
+}
+
+// Similarity sets the minimum similarity a vector must have to be
+// considered a match.
+func (q *KnnQuery) Similarity(similarity float64) *KnnQuery {
+	q.similarity = &similarity
+	return q
+}
+
+// Source returns the JSON serializable content for this query.
+func (q *KnnQuery) Source() (interface{}, error) {
+	// {
+	//   "field": "image-vector",
+	//   "query_vector": [-5, 9, -12],
+	//   "k": 10,
+	//   "num_candidates": 100,
+	//   "similarity": 0.8
+	// }
+	source := make(map[string]interface{})
+	source["field"] = q.field
+	source["query_vector"] = q.queryVector
+	source["k"] = q.k
+	source["num_candidates"] = q.numCandidates
+	if n := len(q.filter); n == 1 {
+		src, err := q.filter[0].Source()
+		if err != nil {
+			return nil, err
+		}
+		source["filter"] = src
+	} else if n > 1 {
+		var filters []interface{}
+		for _, f := range q.filter {
+			src, err := f.Source()
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, src)
+		}
+		source["filter"] = filters
+	}
+	if q.boost != nil {
+		source["boost"] = *q.boost
+	}
+	if q.similarity != nil {
+		source["similarity"] = *q.similarity
+	}
+	return source, nil
+}
@@ -0,0 +1,60 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestKnnQuerySimilarity(t *testing.T) {
+	q := NewKnnQuery("image-vector", []float32{-5, 9, -12}, 10, 100).Similarity(0.8)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"field":"image-vector","k":10,"num_candidates":100,"query_vector":[-5,9,-12],"similarity":0.8}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestKnnSearchResultScores(t *testing.T) {
+	body := `{
+		"took": 3,
+		"hits": {
+			"total": {"value": 2, "relation": "eq"},
+			"max_score": 0.9165,
+			"hits": [
+				{"_index": "images", "_id": "1", "_score": 0.9165, "_source": {"title": "moose"}},
+				{"_index": "images", "_id": "2", "_score": 0.6321, "_source": {"title": "elk"}}
+			]
+		}
+	}`
+	var res SearchResult
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Hits == nil || len(res.Hits.Hits) != 2 {
+		t.Fatalf("expected 2 hits, got %+v", res.Hits)
+	}
+	if res.Hits.MaxScore == nil || *res.Hits.MaxScore != 0.9165 {
+		t.Errorf("expected max score of %v, got %v", 0.9165, res.Hits.MaxScore)
+	}
+	for i, want := range []float64{0.9165, 0.6321} {
+		hit := res.Hits.Hits[i]
+		if hit.Score == nil {
+			t.Fatalf("#%d: expected score, got nil", i)
+		}
+		if *hit.Score != want {
+			t.Errorf("#%d: expected score %v, got %v", i, want, *hit.Score)
+		}
+	}
+}
@@ -77,6 +77,11 @@ func (q *BoolQuery) QueryName(queryName string) *BoolQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *BoolQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Creates the query source for the bool query.
 func (q *BoolQuery) Source() (interface{}, error) {
 	// {
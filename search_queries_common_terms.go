@@ -86,6 +86,11 @@ func (q *CommonTermsQuery) QueryName(queryName string) *CommonTermsQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *CommonTermsQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Creates the query source for the common query.
 func (q *CommonTermsQuery) Source() (interface{}, error) {
 	//  {
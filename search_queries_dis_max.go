@@ -58,6 +58,11 @@ func (q *DisMaxQuery) QueryName(queryName string) *DisMaxQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *DisMaxQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns the JSON serializable content for this query.
 func (q *DisMaxQuery) Source() (interface{}, error) {
 	// {
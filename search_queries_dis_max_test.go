@@ -26,3 +26,23 @@ func TestDisMaxQuery(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestDisMaxQueryWithZeroTieBreaker(t *testing.T) {
+	q := NewDisMaxQuery().
+		Query(NewTermQuery("title", "quick")).
+		Query(NewTermQuery("body", "fox"), NewTermQuery("tags", "animal")).
+		TieBreaker(0)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"dis_max":{"queries":[{"term":{"title":"quick"}},{"term":{"body":"fox"}},{"term":{"tags":"animal"}}],"tie_breaker":0}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
@@ -74,6 +74,11 @@ func (q *DistanceFeatureQuery) QueryName(queryName string) *DistanceFeatureQuery
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *DistanceFeatureQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns JSON for the function score query.
 func (q *DistanceFeatureQuery) Source() (interface{}, error) {
 	// {
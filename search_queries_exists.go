@@ -28,6 +28,11 @@ func (q *ExistsQuery) QueryName(queryName string) *ExistsQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *ExistsQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns the JSON serializable content for this query.
 func (q *ExistsQuery) Source() (interface{}, error) {
 	// {
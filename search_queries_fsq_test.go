@@ -146,6 +146,25 @@ func TestFunctionScoreQueryWithGaussScoreFunc(t *testing.T) {
 	}
 }
 
+func TestFunctionScoreQueryWithGaussScoreFuncAndGeoPointOrigin(t *testing.T) {
+	q := NewFunctionScoreQuery().
+		Query(NewMatchAllQuery()).
+		AddScoreFunc(NewGaussDecayFunction().FieldName("location").Origin(GeoPointFromLatLon(40.7, -74)).Scale("5km").Offset("1km").Decay(0.5))
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"function_score":{"functions":[{"gauss":{"location":{"decay":0.5,"offset":"1km","origin":{"lat":40.7,"lon":-74},"scale":"5km"}}}],"query":{"match_all":{}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestFunctionScoreQueryWithGaussScoreFuncAndMultiValueMode(t *testing.T) {
 	q := NewFunctionScoreQuery().
 		Query(NewTermQuery("name.last", "banon")).
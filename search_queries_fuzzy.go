@@ -72,6 +72,11 @@ func (q *FuzzyQuery) QueryName(queryName string) *FuzzyQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *FuzzyQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns JSON for the function score query.
 func (q *FuzzyQuery) Source() (interface{}, error) {
 	// {
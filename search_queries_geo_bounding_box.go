@@ -4,6 +4,12 @@
 
 package elastic
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // GeoBoundingBoxQuery allows to filter hits based on a point location using
 // a bounding box.
 //
@@ -16,6 +22,7 @@ type GeoBoundingBoxQuery struct {
 	bottomRight      interface{} // can be a GeoPoint, a GeoHash (string), or a lat/lon pair as float64
 	bottomLeft       interface{}
 	wkt              interface{}
+	vertices         map[string]float64 // top, left, bottom, right
 	typ              string
 	validationMethod string
 	ignoreUnmapped   *bool
@@ -31,6 +38,7 @@ func NewGeoBoundingBoxQuery(name string) *GeoBoundingBoxQuery {
 
 // TopLeft position from longitude (left) and latitude (top).
 func (q *GeoBoundingBoxQuery) TopLeft(top, left float64) *GeoBoundingBoxQuery {
+	q.useCorners()
 	q.topLeft = []float64{left, top}
 	return q
 }
@@ -42,12 +50,14 @@ func (q *GeoBoundingBoxQuery) TopLeftFromGeoPoint(point *GeoPoint) *GeoBoundingB
 
 // TopLeftFromGeoHash from a Geo hash.
 func (q *GeoBoundingBoxQuery) TopLeftFromGeoHash(topLeft string) *GeoBoundingBoxQuery {
+	q.useCorners()
 	q.topLeft = topLeft
 	return q
 }
 
 // BottomRight position from longitude (right) and latitude (bottom).
 func (q *GeoBoundingBoxQuery) BottomRight(bottom, right float64) *GeoBoundingBoxQuery {
+	q.useCorners()
 	q.bottomRight = []float64{right, bottom}
 	return q
 }
@@ -59,12 +69,14 @@ func (q *GeoBoundingBoxQuery) BottomRightFromGeoPoint(point *GeoPoint) *GeoBound
 
 // BottomRightFromGeoHash from a Geo hash.
 func (q *GeoBoundingBoxQuery) BottomRightFromGeoHash(bottomRight string) *GeoBoundingBoxQuery {
+	q.useCorners()
 	q.bottomRight = bottomRight
 	return q
 }
 
 // BottomLeft position from longitude (left) and latitude (bottom).
 func (q *GeoBoundingBoxQuery) BottomLeft(bottom, left float64) *GeoBoundingBoxQuery {
+	q.useCorners()
 	q.bottomLeft = []float64{bottom, left}
 	return q
 }
@@ -76,12 +88,14 @@ func (q *GeoBoundingBoxQuery) BottomLeftFromGeoPoint(point *GeoPoint) *GeoBoundi
 
 // BottomLeftFromGeoHash from a Geo hash.
 func (q *GeoBoundingBoxQuery) BottomLeftFromGeoHash(bottomLeft string) *GeoBoundingBoxQuery {
+	q.useCorners()
 	q.bottomLeft = bottomLeft
 	return q
 }
 
 // TopRight position from longitude (right) and latitude (top).
 func (q *GeoBoundingBoxQuery) TopRight(top, right float64) *GeoBoundingBoxQuery {
+	q.useCorners()
 	q.topRight = []float64{right, top}
 	return q
 }
@@ -93,17 +107,86 @@ func (q *GeoBoundingBoxQuery) TopRightFromGeoPoint(point *GeoPoint) *GeoBounding
 
 // TopRightFromGeoHash from a Geo hash.
 func (q *GeoBoundingBoxQuery) TopRightFromGeoHash(topRight string) *GeoBoundingBoxQuery {
+	q.useCorners()
 	q.topRight = topRight
 	return q
 }
 
+// Vertices initializes the bounding box from its vertices, i.e. the
+// latitudes of its top and bottom and the longitudes of its left and
+// right side.
+func (q *GeoBoundingBoxQuery) Vertices(top, left, bottom, right float64) *GeoBoundingBoxQuery {
+	q.wkt = nil
+	q.clearCorners()
+	q.vertices = map[string]float64{
+		"top":    top,
+		"left":   left,
+		"bottom": bottom,
+		"right":  right,
+	}
+	return q
+}
+
 // WKT initializes the bounding box from Well-Known Text (WKT),
 // e.g. "BBOX (-74.1, -71.12, 40.73, 40.01)".
 func (q *GeoBoundingBoxQuery) WKT(wkt interface{}) *GeoBoundingBoxQuery {
+	q.vertices = nil
+	q.clearCorners()
 	q.wkt = wkt
 	return q
 }
 
+// SetWKT parses a bounding box given in Well-Known Text (WKT) as
+// "BBOX (minLon, maxLon, maxLat, minLat)" and sets the top left and
+// bottom right corners accordingly. In contrast to WKT, which passes
+// the text as-is to Elasticsearch, SetWKT returns an error if the text
+// is malformed.
+func (q *GeoBoundingBoxQuery) SetWKT(wkt string) error {
+	s := strings.TrimSpace(wkt)
+	if len(s) < 4 || !strings.EqualFold(s[:4], "BBOX") {
+		return fmt.Errorf("elastic: invalid WKT bounding box %q: missing BBOX", wkt)
+	}
+	s = strings.TrimSpace(s[4:])
+	if !strings.HasPrefix(s, "(") || !strings.HasSuffix(s, ")") {
+		return fmt.Errorf("elastic: invalid WKT bounding box %q: missing parentheses", wkt)
+	}
+	parts := strings.Split(s[1:len(s)-1], ",")
+	if len(parts) != 4 {
+		return fmt.Errorf("elastic: invalid WKT bounding box %q: expected 4 coordinates, got %d", wkt, len(parts))
+	}
+	var coords [4]float64
+	for i, part := range parts {
+		v, err := strconv.ParseFloat(strings.TrimSpace(part), 64)
+		if err != nil {
+			return fmt.Errorf("elastic: invalid WKT bounding box %q: %v", wkt, err)
+		}
+		coords[i] = v
+	}
+	left, right, top, bottom := coords[0], coords[1], coords[2], coords[3]
+	if top < bottom {
+		return fmt.Errorf("elastic: invalid WKT bounding box %q: maxLat %v is less than minLat %v", wkt, top, bottom)
+	}
+	q.clearCorners()
+	q.TopLeft(top, left)
+	q.BottomRight(bottom, right)
+	return nil
+}
+
+// useCorners clears the WKT and the vertices, so that the bounding box
+// is given by its corners. The corners themselves can be combined.
+func (q *GeoBoundingBoxQuery) useCorners() {
+	q.wkt = nil
+	q.vertices = nil
+}
+
+// clearCorners clears the corners of the bounding box.
+func (q *GeoBoundingBoxQuery) clearCorners() {
+	q.topLeft = nil
+	q.topRight = nil
+	q.bottomRight = nil
+	q.bottomLeft = nil
+}
+
 // Type sets the type of executing the geo bounding box. It can be either
 // memory or indexed. It defaults to memory.
 func (q *GeoBoundingBoxQuery) Type(typ string) *GeoBoundingBoxQuery {
@@ -132,6 +215,11 @@ func (q *GeoBoundingBoxQuery) QueryName(queryName string) *GeoBoundingBoxQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *GeoBoundingBoxQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns JSON for the function score query.
 func (q *GeoBoundingBoxQuery) Source() (interface{}, error) {
 	// {
@@ -147,6 +235,10 @@ func (q *GeoBoundingBoxQuery) Source() (interface{}, error) {
 	box := make(map[string]interface{})
 	if q.wkt != nil {
 		box["wkt"] = q.wkt
+	} else if q.vertices != nil {
+		for k, v := range q.vertices {
+			box[k] = v
+		}
 	} else {
 		if q.topLeft != nil {
 			box["top_left"] = q.topLeft
@@ -85,6 +85,74 @@ func TestGeoBoundingBoxQueryWithWKT(t *testing.T) {
 	}
 }
 
+func TestGeoBoundingBoxQueryWithVertices(t *testing.T) {
+	q := NewGeoBoundingBoxQuery("pin.location")
+	q = q.Vertices(40.73, -74.1, 40.01, -71.12)
+	q = q.Type("indexed")
+	q = q.ValidationMethod("COERCE")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"geo_bounding_box":{"pin.location":{"bottom":40.01,"left":-74.1,"right":-71.12,"top":40.73},"type":"indexed","validation_method":"COERCE"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestGeoBoundingBoxQueryLastFormWins(t *testing.T) {
+	tests := []struct {
+		Query    *GeoBoundingBoxQuery
+		Expected string
+	}{
+		{
+			NewGeoBoundingBoxQuery("pin.location").
+				Vertices(40.73, -74.1, 40.01, -71.12).
+				TopLeft(40.73, -74.1).
+				BottomRight(40.01, -71.12),
+			`{"geo_bounding_box":{"pin.location":{"bottom_right":[-71.12,40.01],"top_left":[-74.1,40.73]}}}`,
+		},
+		{
+			NewGeoBoundingBoxQuery("pin.location").
+				WKT("BBOX (-74.1, -71.12, 40.73, 40.01)").
+				TopLeftFromGeoHash("dr5r9ydj2y73").
+				BottomRightFromGeoHash("drj7teegpus6"),
+			`{"geo_bounding_box":{"pin.location":{"bottom_right":"drj7teegpus6","top_left":"dr5r9ydj2y73"}}}`,
+		},
+		{
+			NewGeoBoundingBoxQuery("pin.location").
+				TopLeft(40.73, -74.1).
+				BottomRight(40.01, -71.12).
+				Vertices(40.73, -74.1, 40.01, -71.12),
+			`{"geo_bounding_box":{"pin.location":{"bottom":40.01,"left":-74.1,"right":-71.12,"top":40.73}}}`,
+		},
+		{
+			NewGeoBoundingBoxQuery("pin.location").
+				Vertices(40.73, -74.1, 40.01, -71.12).
+				WKT("BBOX (-74.1, -71.12, 40.73, 40.01)"),
+			`{"geo_bounding_box":{"pin.location":{"wkt":"BBOX (-74.1, -71.12, 40.73, 40.01)"}}}`,
+		},
+	}
+	for i, tt := range tests {
+		src, err := tt.Query.Source()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatalf("#%d: marshaling to JSON failed: %v", i, err)
+		}
+		if got := string(data); got != tt.Expected {
+			t.Errorf("#%d: expected\n%s\n,got:\n%s", i, tt.Expected, got)
+		}
+	}
+}
+
 func TestGeoBoundingBoxQueryWithMixed(t *testing.T) {
 	q := NewGeoBoundingBoxQuery("pin.location")
 	q = q.TopLeftFromGeoPoint(GeoPointFromLatLon(40.73, -74.1))
@@ -124,3 +192,40 @@ func TestGeoBoundingBoxQueryWithParameters(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestGeoBoundingBoxQuerySetWKT(t *testing.T) {
+	q := NewGeoBoundingBoxQuery("pin.location")
+	if err := q.SetWKT("BBOX (-74.1, -71.12, 40.73, 40.01)"); err != nil {
+		t.Fatal(err)
+	}
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"geo_bounding_box":{"pin.location":{"bottom_right":[-71.12,40.01],"top_left":[-74.1,40.73]}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestGeoBoundingBoxQuerySetWKTMalformed(t *testing.T) {
+	tests := []string{
+		"",
+		"POINT (-74.1 40.73)",
+		"BBOX -74.1, -71.12, 40.73, 40.01",
+		"BBOX (-74.1, -71.12, 40.73)",
+		"BBOX (-74.1, -71.12, north, 40.01)",
+		"BBOX (-74.1, -71.12, 40.01, 40.73)",
+	}
+	for i, wkt := range tests {
+		q := NewGeoBoundingBoxQuery("pin.location")
+		if err := q.SetWKT(wkt); err == nil {
+			t.Errorf("#%d: expected error for %q", i, wkt)
+		}
+	}
+}
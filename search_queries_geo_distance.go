@@ -66,6 +66,11 @@ func (q *GeoDistanceQuery) QueryName(queryName string) *GeoDistanceQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *GeoDistanceQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns JSON for the function score query.
 func (q *GeoDistanceQuery) Source() (interface{}, error) {
 	// {
@@ -0,0 +1,101 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "errors"
+
+// GeoGridQuery matches geo_point and geo_shape values that intersect
+// a grid cell from a geotile, geohash or geohex grid aggregation.
+//
+// For more details, see:
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/query-dsl-geo-grid-query.html
+type GeoGridQuery struct {
+	name      string
+	gridType  string // geotile, geohash, or geohex
+	gridKey   string
+	boost     *float64
+	queryName string
+}
+
+// NewGeoGridQuery creates and initializes a new GeoGridQuery
+// on the given field.
+func NewGeoGridQuery(name string) *GeoGridQuery {
+	return &GeoGridQuery{
+		name: name,
+	}
+}
+
+// Geotile sets the cell key of a geotile grid, e.g. "6/32/22".
+// It replaces a cell set via Geohash or Geohex.
+func (q *GeoGridQuery) Geotile(key string) *GeoGridQuery {
+	q.gridType = "geotile"
+	q.gridKey = key
+	return q
+}
+
+// Geohash sets the cell key of a geohash grid, e.g. "u0".
+// It replaces a cell set via Geotile or Geohex.
+func (q *GeoGridQuery) Geohash(key string) *GeoGridQuery {
+	q.gridType = "geohash"
+	q.gridKey = key
+	return q
+}
+
+// Geohex sets the cell key of an H3 geohex grid, e.g. "811fbffffffffff".
+// It replaces a cell set via Geotile or Geohash.
+func (q *GeoGridQuery) Geohex(key string) *GeoGridQuery {
+	q.gridType = "geohex"
+	q.gridKey = key
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *GeoGridQuery) Boost(boost float64) *GeoGridQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched queries per hit.
+func (q *GeoGridQuery) QueryName(queryName string) *GeoGridQuery {
+	q.queryName = queryName
+	return q
+}
+
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *GeoGridQuery) GetQueryName() string {
+	return q.queryName
+}
+
+// Source returns JSON for the geo_grid query.
+func (q *GeoGridQuery) Source() (interface{}, error) {
+	// {
+	//   "geo_grid" : {
+	//     "location" : {
+	//       "geotile" : "6/32/22"
+	//     }
+	//   }
+	// }
+	if q.gridType == "" {
+		return nil, errors.New("elastic: geo_grid query requires a geotile, geohash, or geohex cell")
+	}
+
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["geo_grid"] = params
+
+	params[q.name] = map[string]interface{}{
+		q.gridType: q.gridKey,
+	}
+
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+
+	return source, nil
+}
@@ -0,0 +1,55 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGeoGridQuery(t *testing.T) {
+	tests := []struct {
+		Query    *GeoGridQuery
+		Expected string
+	}{
+		{
+			NewGeoGridQuery("location").Geotile("6/32/22"),
+			`{"geo_grid":{"location":{"geotile":"6/32/22"}}}`,
+		},
+		{
+			NewGeoGridQuery("location").Geohash("u0"),
+			`{"geo_grid":{"location":{"geohash":"u0"}}}`,
+		},
+		{
+			NewGeoGridQuery("location").Geohex("811fbffffffffff").Boost(1.5).QueryName("my_query"),
+			`{"geo_grid":{"_name":"my_query","boost":1.5,"location":{"geohex":"811fbffffffffff"}}}`,
+		},
+		{
+			NewGeoGridQuery("location").Geotile("6/32/22").Geohash("u0"),
+			`{"geo_grid":{"location":{"geohash":"u0"}}}`,
+		},
+	}
+
+	for i, tt := range tests {
+		src, err := tt.Query.Source()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatalf("#%d: marshaling to JSON failed: %v", i, err)
+		}
+		if got := string(data); got != tt.Expected {
+			t.Errorf("#%d: expected\n%s\n,got:\n%s", i, tt.Expected, got)
+		}
+	}
+}
+
+func TestGeoGridQueryWithoutCell(t *testing.T) {
+	q := NewGeoGridQuery("location")
+	if _, err := q.Source(); err == nil {
+		t.Fatal("expected error when no grid cell is set")
+	}
+}
@@ -39,6 +39,11 @@ func (q *GeoPolygonQuery) QueryName(queryName string) *GeoPolygonQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *GeoPolygonQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns JSON for the function score query.
 func (q *GeoPolygonQuery) Source() (interface{}, error) {
 	// "geo_polygon" : {
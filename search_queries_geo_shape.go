@@ -0,0 +1,117 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// GeoShapeQuery filters documents indexed using the geo_shape or
+// geo_point type by a given shape.
+//
+// For more details, see:
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/query-dsl-geo-shape-query.html
+type GeoShapeQuery struct {
+	shapeSource
+	name           string
+	ignoreUnmapped *bool
+	boost          *float64
+	queryName      string
+}
+
+// NewGeoShapeQuery creates and initializes a new GeoShapeQuery
+// on the given field.
+func NewGeoShapeQuery(name string) *GeoShapeQuery {
+	return &GeoShapeQuery{
+		name: name,
+	}
+}
+
+// SetShape sets the shape as GeoJSON, e.g.
+// {"type":"envelope","coordinates":[[13.0,53.0],[14.0,52.0]]}.
+// Only one of SetShape, SetShapeWKT, and SetIndexedShape takes effect;
+// the last one called wins.
+func (q *GeoShapeQuery) SetShape(shape interface{}) *GeoShapeQuery {
+	q.setShape(shape)
+	return q
+}
+
+// SetIndexedShape uses a pre-indexed shape, e.g. a country polygon,
+// from the document with the given id in index. The path is the field
+// holding the shape and defaults to "shape" in Elasticsearch.
+func (q *GeoShapeQuery) SetIndexedShape(id, index, path string) *GeoShapeQuery {
+	q.setIndexedShape(id, index, path)
+	return q
+}
+
+// SetShapeWKT sets the shape in Well-Known Text (WKT) format,
+// e.g. "POLYGON ((100.0 0.0, 101.0 0.0, 101.0 1.0, 100.0 1.0, 100.0 0.0))".
+// Elasticsearch accepts POINT, LINESTRING, POLYGON, MULTIPOINT,
+// MULTILINESTRING, MULTIPOLYGON, GEOMETRYCOLLECTION, and BBOX.
+func (q *GeoShapeQuery) SetShapeWKT(wkt string) *GeoShapeQuery {
+	q.setShape(wkt)
+	return q
+}
+
+// Relation sets the spatial relation operator, i.e. one of
+// "intersects" (default), "disjoint", "within", or "contains".
+func (q *GeoShapeQuery) Relation(relation string) *GeoShapeQuery {
+	q.relation = relation
+	return q
+}
+
+// IgnoreUnmapped indicates whether to ignore unmapped fields (and run a
+// MatchNoDocsQuery in place of this).
+func (q *GeoShapeQuery) IgnoreUnmapped(ignoreUnmapped bool) *GeoShapeQuery {
+	q.ignoreUnmapped = &ignoreUnmapped
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *GeoShapeQuery) Boost(boost float64) *GeoShapeQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched queries per hit.
+func (q *GeoShapeQuery) QueryName(queryName string) *GeoShapeQuery {
+	q.queryName = queryName
+	return q
+}
+
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *GeoShapeQuery) GetQueryName() string {
+	return q.queryName
+}
+
+// Source returns JSON for the geo_shape query.
+func (q *GeoShapeQuery) Source() (interface{}, error) {
+	// {
+	//   "geo_shape" : {
+	//     "location" : {
+	//       "shape" : "POLYGON ((...))",
+	//       "relation" : "within"
+	//     }
+	//   }
+	// }
+	field, err := q.shapeSource.source("geo_shape")
+	if err != nil {
+		return nil, err
+	}
+
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["geo_shape"] = params
+	params[q.name] = field
+
+	if q.ignoreUnmapped != nil {
+		params["ignore_unmapped"] = *q.ignoreUnmapped
+	}
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+
+	return source, nil
+}
@@ -0,0 +1,78 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGeoShapeQueryWithWKTPolygon(t *testing.T) {
+	q := NewGeoShapeQuery("location").
+		SetShapeWKT("POLYGON ((100.0 0.0, 101.0 0.0, 101.0 1.0, 100.0 1.0, 100.0 0.0))").
+		Relation("within")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"geo_shape":{"location":{"relation":"within","shape":"POLYGON ((100.0 0.0, 101.0 0.0, 101.0 1.0, 100.0 1.0, 100.0 0.0))"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestGeoShapeQueryWithGeoJSONShape(t *testing.T) {
+	q := NewGeoShapeQuery("location").
+		SetShape(map[string]interface{}{
+			"type":        "envelope",
+			"coordinates": [][]float64{{13.0, 53.0}, {14.0, 52.0}},
+		}).
+		Relation("within").
+		QueryName("my_query")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"geo_shape":{"_name":"my_query","location":{"relation":"within","shape":{"coordinates":[[13,53],[14,52]],"type":"envelope"}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestGeoShapeQueryWithIndexedShape(t *testing.T) {
+	q := NewGeoShapeQuery("location").
+		SetShapeWKT("POINT (13.0 53.0)").
+		SetIndexedShape("deu", "shapes", "location")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"geo_shape":{"location":{"indexed_shape":{"id":"deu","index":"shapes","path":"location"}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestGeoShapeQueryWithoutShape(t *testing.T) {
+	q := NewGeoShapeQuery("location")
+	if _, err := q.Source(); err == nil {
+		t.Fatal("expected error when no shape is set")
+	}
+}
@@ -72,6 +72,11 @@ func (q *HasChildQuery) QueryName(queryName string) *HasChildQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *HasChildQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // InnerHit sets the inner hit definition in the scope of this query and
 // reusing the defined type and query.
 func (q *HasChildQuery) InnerHit(innerHit *InnerHit) *HasChildQuery {
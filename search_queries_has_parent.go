@@ -49,6 +49,11 @@ func (q *HasParentQuery) QueryName(queryName string) *HasParentQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *HasParentQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // InnerHit sets the inner hit definition in the scope of this query and
 // reusing the defined type and query.
 func (q *HasParentQuery) InnerHit(innerHit *InnerHit) *HasParentQuery {
@@ -45,6 +45,11 @@ func (q *IdsQuery) QueryName(queryName string) *IdsQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *IdsQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns JSON for the function score query.
 func (q *IdsQuery) Source() (interface{}, error) {
 	// {
@@ -132,6 +132,11 @@ func (q *MatchQuery) QueryName(queryName string) *MatchQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *MatchQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns JSON for the function score query.
 func (q *MatchQuery) Source() (interface{}, error) {
 	// {"match":{"name":{"query":"value","type":"boolean/phrase"}}}
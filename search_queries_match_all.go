@@ -35,6 +35,11 @@ func (q *MatchAllQuery) QueryName(name string) *MatchAllQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *MatchAllQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns JSON for the match all query.
 func (q *MatchAllQuery) Source() (interface{}, error) {
 	// {
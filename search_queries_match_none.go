@@ -24,6 +24,11 @@ func (q *MatchNoneQuery) QueryName(name string) *MatchNoneQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *MatchNoneQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns JSON for the match none query.
 func (q MatchNoneQuery) Source() (interface{}, error) {
 	// {
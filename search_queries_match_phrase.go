@@ -56,6 +56,11 @@ func (q *MatchPhraseQuery) QueryName(queryName string) *MatchPhraseQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *MatchPhraseQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns JSON for the function score query.
 func (q *MatchPhraseQuery) Source() (interface{}, error) {
 	// {"match_phrase":{"name":{"query":"value","analyzer":"my_analyzer"}}}
@@ -56,6 +56,11 @@ func (q *MatchPhrasePrefixQuery) QueryName(queryName string) *MatchPhrasePrefixQ
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *MatchPhrasePrefixQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns JSON for the function score query.
 func (q *MatchPhrasePrefixQuery) Source() (interface{}, error) {
 	// {"match_phrase_prefix":{"name":{"query":"value","max_expansions":10}}}
@@ -194,6 +194,11 @@ func (q *MoreLikeThisQuery) QueryName(queryName string) *MoreLikeThisQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *MoreLikeThisQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source creates the source for the MLT query.
 // It may return an error if the caller forgot to specify any documents to
 // be "liked" in the MoreLikeThisQuery.
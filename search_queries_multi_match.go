@@ -182,6 +182,11 @@ func (q *MultiMatchQuery) QueryName(queryName string) *MultiMatchQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *MultiMatchQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns JSON for the query.
 func (q *MultiMatchQuery) Source() (interface{}, error) {
 	//
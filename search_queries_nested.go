@@ -45,6 +45,11 @@ func (q *NestedQuery) QueryName(queryName string) *NestedQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *NestedQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // InnerHit sets the inner hit definition in the scope of this nested query
 // and reusing the defined path and query.
 func (q *NestedQuery) InnerHit(innerHit *InnerHit) *NestedQuery {
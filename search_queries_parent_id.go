@@ -58,6 +58,11 @@ func (q *ParentIdQuery) QueryName(queryName string) *ParentIdQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *ParentIdQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // InnerHit sets the inner hit definition in the scope of this query and
 // reusing the defined type and query.
 func (q *ParentIdQuery) InnerHit(innerHit *InnerHit) *ParentIdQuery {
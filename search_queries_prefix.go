@@ -46,6 +46,11 @@ func (q *PrefixQuery) QueryName(queryName string) *PrefixQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *PrefixQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns JSON for the query.
 func (q *PrefixQuery) Source() (interface{}, error) {
 	source := make(map[string]interface{})
@@ -224,6 +224,11 @@ func (q *QueryStringQuery) QueryName(queryName string) *QueryStringQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *QueryStringQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Locale specifies the locale to be used for string conversions.
 //
 // Deprecated: Decision is now made by the analyzer.
@@ -99,6 +99,11 @@ func (q *RangeQuery) QueryName(queryName string) *RangeQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *RangeQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // TimeZone is used for date fields. In that case, we can adjust the
 // from/to fields using a timezone.
 func (q *RangeQuery) TimeZone(timeZone string) *RangeQuery {
@@ -38,6 +38,30 @@ func (q *RankFeatureQuery) ScoreFunction(f RankFeatureScoreFunction) *RankFeatur
 	return q
 }
 
+// Saturation is a shortcut for using a RankFeatureSaturationScoreFunction
+// with the given pivot as score function.
+func (q *RankFeatureQuery) Saturation(pivot float64) *RankFeatureQuery {
+	return q.ScoreFunction(NewRankFeatureSaturationScoreFunction().Pivot(pivot))
+}
+
+// Log is a shortcut for using a RankFeatureLogScoreFunction with the
+// given scaling factor as score function.
+func (q *RankFeatureQuery) Log(scalingFactor float64) *RankFeatureQuery {
+	return q.ScoreFunction(NewRankFeatureLogScoreFunction(scalingFactor))
+}
+
+// Sigmoid is a shortcut for using a RankFeatureSigmoidScoreFunction with
+// the given pivot and exponent as score function.
+func (q *RankFeatureQuery) Sigmoid(pivot, exponent float64) *RankFeatureQuery {
+	return q.ScoreFunction(NewRankFeatureSigmoidScoreFunction(pivot, exponent))
+}
+
+// Linear is a shortcut for using a RankFeatureLinearScoreFunction as
+// score function.
+func (q *RankFeatureQuery) Linear() *RankFeatureQuery {
+	return q.ScoreFunction(NewRankFeatureLinearScoreFunction())
+}
+
 // Boost sets the boost for this query.
 func (q *RankFeatureQuery) Boost(boost float64) *RankFeatureQuery {
 	q.boost = &boost
@@ -51,6 +75,11 @@ func (q *RankFeatureQuery) QueryName(queryName string) *RankFeatureQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *RankFeatureQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns the JSON serializable content for this query.
 func (q *RankFeatureQuery) Source() (interface{}, error) {
 	// {
@@ -49,6 +49,26 @@ func TestRankFeatureQueryTest(t *testing.T) {
 			Query:    NewRankFeatureQuery("pagerank").ScoreFunction(NewRankFeatureLinearScoreFunction()),
 			Expected: `{"rank_feature":{"field":"pagerank","linear":{}}}`,
 		},
+		// #7
+		{
+			Query:    NewRankFeatureQuery("pagerank").Saturation(8),
+			Expected: `{"rank_feature":{"field":"pagerank","saturation":{"pivot":8}}}`,
+		},
+		// #8
+		{
+			Query:    NewRankFeatureQuery("pagerank").Log(4),
+			Expected: `{"rank_feature":{"field":"pagerank","log":{"scaling_factor":4}}}`,
+		},
+		// #9
+		{
+			Query:    NewRankFeatureQuery("pagerank").Sigmoid(7, 0.6),
+			Expected: `{"rank_feature":{"field":"pagerank","sigmoid":{"exponent":0.6,"pivot":7}}}`,
+		},
+		// #10
+		{
+			Query:    NewRankFeatureQuery("pagerank").Linear(),
+			Expected: `{"rank_feature":{"field":"pagerank","linear":{}}}`,
+		},
 	}
 
 	for i, tt := range tests {
@@ -59,6 +59,11 @@ func (q *RegexpQuery) QueryName(queryName string) *RegexpQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *RegexpQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns the JSON-serializable query data.
 func (q *RegexpQuery) Source() (interface{}, error) {
 	source := make(map[string]interface{})
@@ -29,6 +29,11 @@ func (q *ScriptQuery) QueryName(queryName string) *ScriptQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *ScriptQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns JSON for the query.
 func (q *ScriptQuery) Source() (interface{}, error) {
 	if q.script == nil {
@@ -14,11 +14,12 @@ import "errors"
 // For more details, see
 // https://www.elastic.co/guide/en/elasticsearch/reference/7.4/query-dsl-script-score-query.html
 type ScriptScoreQuery struct {
-	query     Query
-	script    *Script
-	minScore  *float64
-	boost     *float64
-	queryName string
+	query        Query
+	script       *Script
+	scriptParams map[string]interface{}
+	minScore     *float64
+	boost        *float64
+	queryName    string
 }
 
 // NewScriptScoreQuery creates and initializes a new script_score query.
@@ -41,6 +42,15 @@ func (q *ScriptScoreQuery) Script(script *Script) *ScriptScoreQuery {
 	return q
 }
 
+// ScriptParams sets parameters that are merged into the params of the
+// script when serializing the query. Parameters given here take
+// precedence over parameters of the same name set on the script.
+// The script itself is not modified.
+func (q *ScriptScoreQuery) ScriptParams(params map[string]interface{}) *ScriptScoreQuery {
+	q.scriptParams = params
+	return q
+}
+
 // MinScore sets the minimum score.
 func (q *ScriptScoreQuery) MinScore(minScore float64) *ScriptScoreQuery {
 	q.minScore = &minScore
@@ -59,6 +69,11 @@ func (q *ScriptScoreQuery) QueryName(queryName string) *ScriptScoreQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *ScriptScoreQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns JSON for the function score query.
 func (q *ScriptScoreQuery) Source() (interface{}, error) {
 	// {
@@ -89,7 +104,20 @@ func (q *ScriptScoreQuery) Source() (interface{}, error) {
 		query["query"] = src
 	}
 
-	if src, err := q.script.Source(); err != nil {
+	script := q.script
+	if len(q.scriptParams) > 0 {
+		merged := make(map[string]interface{}, len(script.params)+len(q.scriptParams))
+		for k, v := range script.params {
+			merged[k] = v
+		}
+		for k, v := range q.scriptParams {
+			merged[k] = v
+		}
+		copied := *script
+		copied.params = merged
+		script = &copied
+	}
+	if src, err := script.Source(); err != nil {
 		return nil, err
 	} else {
 		query["script"] = src
@@ -28,3 +28,28 @@ func TestScriptScoreQuery(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestScriptScoreQueryWithZeroMinScoreAndParams(t *testing.T) {
+	script := NewScript("doc['likes'].value * params.factor").Param("offset", 1)
+	q := NewScriptScoreQuery(NewMatchAllQuery(), script).
+		MinScore(0).
+		ScriptParams(map[string]interface{}{"factor": 1.5})
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"script_score":{"min_score":0,"query":{"match_all":{}},"script":{"params":{"factor":1.5,"offset":1},"source":"doc['likes'].value * params.factor"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+
+	// The script passed in must not be modified
+	if _, found := script.params["factor"]; found {
+		t.Errorf("expected script params not to be modified, got %v", script.params)
+	}
+}
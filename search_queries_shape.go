@@ -0,0 +1,159 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "fmt"
+
+// ShapeQuery queries documents that contain fields indexed using the
+// shape type, i.e. arbitrary cartesian (non-geographic) geometries.
+//
+// For more details, see:
+// https://www.elastic.co/guide/en/elasticsearch/reference/7.x/query-dsl-shape-query.html
+type ShapeQuery struct {
+	shapeSource
+	name           string
+	ignoreUnmapped *bool
+	boost          *float64
+	queryName      string
+}
+
+// NewShapeQuery creates and initializes a new ShapeQuery
+// on the given field.
+func NewShapeQuery(name string) *ShapeQuery {
+	return &ShapeQuery{
+		name: name,
+	}
+}
+
+// SetShape sets an inline shape, e.g. a GeoJSON object like
+// {"type":"envelope","coordinates":[[1355.0,5355.0],[1400.0,5200.0]]}.
+// It clears a shape set via SetIndexedShape.
+func (q *ShapeQuery) SetShape(shape interface{}) *ShapeQuery {
+	q.setShape(shape)
+	return q
+}
+
+// SetIndexedShape references a shape that is stored in another document,
+// given by its id and index. The path is the field that holds the shape;
+// if empty, Elasticsearch uses "shape". It clears a shape set via SetShape.
+func (q *ShapeQuery) SetIndexedShape(id, index, path string) *ShapeQuery {
+	q.setIndexedShape(id, index, path)
+	return q
+}
+
+// Relation sets the spatial relation operator, i.e. one of
+// "intersects" (default), "disjoint", "within", or "contains".
+func (q *ShapeQuery) Relation(relation string) *ShapeQuery {
+	q.relation = relation
+	return q
+}
+
+// IgnoreUnmapped indicates whether to ignore unmapped fields (and run a
+// MatchNoDocsQuery in place of this).
+func (q *ShapeQuery) IgnoreUnmapped(ignoreUnmapped bool) *ShapeQuery {
+	q.ignoreUnmapped = &ignoreUnmapped
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *ShapeQuery) Boost(boost float64) *ShapeQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched queries per hit.
+func (q *ShapeQuery) QueryName(queryName string) *ShapeQuery {
+	q.queryName = queryName
+	return q
+}
+
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *ShapeQuery) GetQueryName() string {
+	return q.queryName
+}
+
+// Source returns JSON for the shape query.
+func (q *ShapeQuery) Source() (interface{}, error) {
+	// {
+	//   "shape" : {
+	//     "geometry" : {
+	//       "shape" : {
+	//         "type" : "envelope",
+	//         "coordinates" : [[1355.0, 5355.0], [1400.0, 5200.0]]
+	//       },
+	//       "relation" : "within"
+	//     }
+	//   }
+	// }
+	field, err := q.shapeSource.source("shape")
+	if err != nil {
+		return nil, err
+	}
+
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["shape"] = params
+	params[q.name] = field
+
+	if q.ignoreUnmapped != nil {
+		params["ignore_unmapped"] = *q.ignoreUnmapped
+	}
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+
+	return source, nil
+}
+
+// shapeSource is the part of a shape or geo_shape query that describes
+// the shape to match against, i.e. either an inline shape or a reference
+// to a shape indexed in another document, plus the spatial relation.
+type shapeSource struct {
+	shape        interface{} // GeoJSON object or WKT string
+	indexedShape map[string]interface{}
+	relation     string
+}
+
+// setShape sets an inline shape and clears an indexed shape.
+func (s *shapeSource) setShape(shape interface{}) {
+	s.shape = shape
+	s.indexedShape = nil
+}
+
+// setIndexedShape sets a reference to an indexed shape and clears
+// an inline shape.
+func (s *shapeSource) setIndexedShape(id, index, path string) {
+	s.shape = nil
+	s.indexedShape = map[string]interface{}{
+		"id":    id,
+		"index": index,
+	}
+	if path != "" {
+		s.indexedShape["path"] = path
+	}
+}
+
+// source returns the body for the field of the query, e.g.
+// {"shape":{...},"relation":"within"}. The kind of the query, e.g.
+// "geo_shape", is used in the error returned if no shape is set.
+func (s *shapeSource) source(kind string) (map[string]interface{}, error) {
+	if s.shape == nil && s.indexedShape == nil {
+		return nil, fmt.Errorf("elastic: %s query requires a shape or an indexed shape", kind)
+	}
+	field := make(map[string]interface{})
+	if s.indexedShape != nil {
+		field["indexed_shape"] = s.indexedShape
+	} else {
+		field["shape"] = s.shape
+	}
+	if s.relation != "" {
+		field["relation"] = s.relation
+	}
+	return field, nil
+}
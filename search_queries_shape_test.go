@@ -0,0 +1,59 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestShapeQueryWithInlineShape(t *testing.T) {
+	q := NewShapeQuery("geometry").
+		SetShape(map[string]interface{}{
+			"type":        "envelope",
+			"coordinates": [][]float64{{1355.0, 5355.0}, {1400.0, 5200.0}},
+		}).
+		Relation("within")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"shape":{"geometry":{"relation":"within","shape":{"coordinates":[[1355,5355],[1400,5200]],"type":"envelope"}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestShapeQueryWithIndexedShape(t *testing.T) {
+	q := NewShapeQuery("geometry").
+		SetIndexedShape("deu", "shapes", "coordinates").
+		Relation("intersects").
+		QueryName("in_germany")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"shape":{"_name":"in_germany","geometry":{"indexed_shape":{"id":"deu","index":"shapes","path":"coordinates"},"relation":"intersects"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestShapeQueryWithoutShape(t *testing.T) {
+	q := NewShapeQuery("geometry")
+	if _, err := q.Source(); err == nil {
+		t.Fatal("expected error when no shape is set")
+	}
+}
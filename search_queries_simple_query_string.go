@@ -81,6 +81,11 @@ func (q *SimpleQueryStringQuery) QueryName(queryName string) *SimpleQueryStringQ
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *SimpleQueryStringQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Analyzer specifies the analyzer to use for the query.
 func (q *SimpleQueryStringQuery) Analyzer(analyzer string) *SimpleQueryStringQuery {
 	q.analyzer = analyzer
@@ -0,0 +1,83 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// SpanContainingQuery returns matches which enclose another span query.
+// The big and little clauses can be any span type query. Matching spans
+// from big that contain matches from little are returned.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.7/query-dsl-span-containing-query.html
+// for details.
+type SpanContainingQuery struct {
+	little    Query
+	big       Query
+	boost     *float64
+	queryName string
+}
+
+// NewSpanContainingQuery creates a new SpanContainingQuery.
+func NewSpanContainingQuery() *SpanContainingQuery {
+	return &SpanContainingQuery{}
+}
+
+// Little sets the inner span query, e.g. a SpanTermQuery.
+func (q *SpanContainingQuery) Little(query Query) *SpanContainingQuery {
+	q.little = query
+	return q
+}
+
+// Big sets the outer span query, e.g. a SpanNearQuery.
+func (q *SpanContainingQuery) Big(query Query) *SpanContainingQuery {
+	q.big = query
+	return q
+}
+
+// Boost sets the boost for this query.
+func (q *SpanContainingQuery) Boost(boost float64) *SpanContainingQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used when
+// searching for matched_filters per hit.
+func (q *SpanContainingQuery) QueryName(queryName string) *SpanContainingQuery {
+	q.queryName = queryName
+	return q
+}
+
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *SpanContainingQuery) GetQueryName() string {
+	return q.queryName
+}
+
+// Source returns the JSON body.
+func (q *SpanContainingQuery) Source() (interface{}, error) {
+	m := make(map[string]interface{})
+	c := make(map[string]interface{})
+
+	if q.little != nil {
+		src, err := q.little.Source()
+		if err != nil {
+			return nil, err
+		}
+		c["little"] = src
+	}
+	if q.big != nil {
+		src, err := q.big.Source()
+		if err != nil {
+			return nil, err
+		}
+		c["big"] = src
+	}
+
+	if v := q.boost; v != nil {
+		c["boost"] = *v
+	}
+	if v := q.queryName; v != "" {
+		c["_name"] = v
+	}
+	m["span_containing"] = c
+	return m, nil
+}
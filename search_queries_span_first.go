@@ -49,6 +49,11 @@ func (q *SpanFirstQuery) QueryName(queryName string) *SpanFirstQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *SpanFirstQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns the JSON body.
 func (q *SpanFirstQuery) Source() (interface{}, error) {
 	m := make(map[string]interface{})
@@ -63,6 +63,11 @@ func (q *SpanNearQuery) QueryName(queryName string) *SpanNearQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *SpanNearQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns the JSON body.
 func (q *SpanNearQuery) Source() (interface{}, error) {
 	m := make(map[string]interface{})
@@ -52,6 +52,11 @@ func (q *SpanTermQuery) QueryName(queryName string) *SpanTermQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *SpanTermQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns the JSON body.
 func (q *SpanTermQuery) Source() (interface{}, error) {
 	m := make(map[string]interface{})
@@ -0,0 +1,33 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSpanWithinQuery(t *testing.T) {
+	q := NewSpanWithinQuery().
+		Little(NewSpanTermQuery("field1", "foo")).
+		Big(NewSpanNearQuery(
+			NewSpanTermQuery("field1", "bar"),
+			NewSpanTermQuery("field1", "baz"),
+		).Slop(5).InOrder(true)).
+		Boost(2)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"span_within":{"big":{"span_near":{"clauses":[{"span_term":{"field1":{"value":"bar"}}},{"span_term":{"field1":{"value":"baz"}}}],"in_order":true,"slop":5}},"boost":2,"little":{"span_term":{"field1":{"value":"foo"}}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
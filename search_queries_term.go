@@ -40,6 +40,11 @@ func (q *TermQuery) QueryName(queryName string) *TermQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *TermQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source returns JSON for the query.
 func (q *TermQuery) Source() (interface{}, error) {
 	// {"term":{"name":"value"}}
@@ -42,6 +42,33 @@ func NewTermsQueryFromStrings(name string, values ...string) *TermsQuery {
 	return q
 }
 
+// ChunkedTermsQuery splits values into terms queries of at most chunkSize
+// values each and combines them in the should clause of a BoolQuery.
+// Use it to stay below index.max_terms_count when filtering by a very
+// large number of values. If the values are stored in a document anyway,
+// consider a terms lookup (see TermsLookup) instead.
+//
+// If chunkSize is zero or negative, all values end up in a single chunk.
+// If values is empty, the query contains a single terms query with no
+// values, so that it matches no documents, just like a terms query would.
+func ChunkedTermsQuery(name string, values []string, chunkSize int) *BoolQuery {
+	q := NewBoolQuery()
+	if len(values) == 0 {
+		return q.Should(NewTermsQueryFromStrings(name))
+	}
+	if chunkSize <= 0 {
+		chunkSize = len(values)
+	}
+	for start := 0; start < len(values); start += chunkSize {
+		end := start + chunkSize
+		if end > len(values) {
+			end = len(values)
+		}
+		q = q.Should(NewTermsQueryFromStrings(name, values[start:end]...))
+	}
+	return q
+}
+
 // TermsLookup adds terms lookup details to the query.
 func (q *TermsQuery) TermsLookup(lookup *TermsLookup) *TermsQuery {
 	q.termsLookup = lookup
@@ -61,6 +88,11 @@ func (q *TermsQuery) QueryName(queryName string) *TermsQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *TermsQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Creates the query source for the term query.
 func (q *TermsQuery) Source() (interface{}, error) {
 	// {"terms":{"name":["value1","value2"]}}
@@ -59,6 +59,11 @@ func (q *TermsSetQuery) QueryName(queryName string) *TermsSetQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *TermsSetQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // Source creates the query source for the term query.
 func (q *TermsSetQuery) Source() (interface{}, error) {
 	// {"terms_set":{"codes":{"terms":["abc","def"],"minimum_should_match_field":"required_matches"}}}
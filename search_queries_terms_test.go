@@ -98,3 +98,79 @@ func TestTermQuerysWithOptions(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestChunkedTermsQuery(t *testing.T) {
+	values := []string{"1", "2", "3", "4", "5", "6", "7"}
+	tests := []struct {
+		ChunkSize int
+		Chunks    int
+	}{
+		{3, 3},
+		{7, 1},
+		{10, 1},
+		{0, 1},
+		{-1, 1},
+		{1, 7},
+	}
+	for i, tt := range tests {
+		src, err := ChunkedTermsQuery("_id", values, tt.ChunkSize).Source()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatalf("#%d: marshaling to JSON failed: %v", i, err)
+		}
+		var body struct {
+			Bool struct {
+				Should json.RawMessage `json:"should"`
+			} `json:"bool"`
+		}
+		if err := json.Unmarshal(data, &body); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		var chunks []interface{}
+		if err := json.Unmarshal(body.Bool.Should, &chunks); err != nil {
+			// A single should clause is serialized as an object
+			chunks = []interface{}{body.Bool.Should}
+		}
+		if want, have := tt.Chunks, len(chunks); want != have {
+			t.Errorf("#%d: expected %d chunks, got %d: %s", i, want, have, data)
+		}
+	}
+}
+
+func TestChunkedTermsQuerySource(t *testing.T) {
+	q := ChunkedTermsQuery("_id", []string{"1", "2", "3"}, 2)
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"bool":{"should":[{"terms":{"_id":["1","2"]}},{"terms":{"_id":["3"]}}]}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestChunkedTermsQueryWithoutValues(t *testing.T) {
+	for _, chunkSize := range []int{-1, 0, 2} {
+		src, err := ChunkedTermsQuery("_id", nil, chunkSize).Source()
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatalf("marshaling to JSON failed: %v", err)
+		}
+		got := string(data)
+		expected := `{"bool":{"should":{"terms":{"_id":[]}}}}`
+		if got != expected {
+			t.Errorf("chunkSize=%d: expected\n%s\n,got:\n%s", chunkSize, expected, got)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+// TextExpansionQuery uses a natural language processing model, e.g. ELSER,
+// to convert the query text into a list of token-weight pairs which are
+// then used to query a sparse vector or rank features field.
+//
+// For details, see
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/query-dsl-text-expansion-query.html
+type TextExpansionQuery struct {
+	field     string
+	modelText string
+	modelId   string
+	boost     *float64
+	queryName string
+}
+
+// NewTextExpansionQuery creates and initializes a new TextExpansionQuery.
+func NewTextExpansionQuery(field, modelText, modelId string) *TextExpansionQuery {
+	return &TextExpansionQuery{
+		field:     field,
+		modelText: modelText,
+		modelId:   modelId,
+	}
+}
+
+// Boost sets the boost for this query.
+func (q *TextExpansionQuery) Boost(boost float64) *TextExpansionQuery {
+	q.boost = &boost
+	return q
+}
+
+// QueryName sets the query name for the filter that can be used
+// when searching for matched_filters per hit
+func (q *TextExpansionQuery) QueryName(queryName string) *TextExpansionQuery {
+	q.queryName = queryName
+	return q
+}
+
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *TextExpansionQuery) GetQueryName() string {
+	return q.queryName
+}
+
+// Source returns JSON for the query.
+func (q *TextExpansionQuery) Source() (interface{}, error) {
+	// {
+	//   "text_expansion": {
+	//     "ml.tokens": {
+	//       "model_id": ".elser_model_1",
+	//       "model_text": "How is the weather in Jamaica?"
+	//     }
+	//   }
+	// }
+	source := make(map[string]interface{})
+	teq := make(map[string]interface{})
+	source["text_expansion"] = teq
+
+	params := make(map[string]interface{})
+	params["model_id"] = q.modelId
+	params["model_text"] = q.modelText
+	if q.boost != nil {
+		params["boost"] = *q.boost
+	}
+	if q.queryName != "" {
+		params["_name"] = q.queryName
+	}
+	teq[q.field] = params
+	return source, nil
+}
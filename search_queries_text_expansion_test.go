@@ -0,0 +1,44 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTextExpansionQuery(t *testing.T) {
+	q := NewTextExpansionQuery("ml.tokens", "How is the weather in Jamaica?", ".elser_model_1")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"text_expansion":{"ml.tokens":{"model_id":".elser_model_1","model_text":"How is the weather in Jamaica?"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestTextExpansionQueryWithBoostAndName(t *testing.T) {
+	q := NewTextExpansionQuery("ml.tokens", "weather", ".elser_model_1").Boost(2).QueryName("elser")
+	src, err := q.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"text_expansion":{"ml.tokens":{"_name":"elser","boost":2,"model_id":".elser_model_1","model_text":"weather"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
@@ -48,6 +48,11 @@ func (q *WildcardQuery) QueryName(queryName string) *WildcardQuery {
 	return q
 }
 
+// GetQueryName returns the query name set via QueryName, if any.
+func (q *WildcardQuery) GetQueryName() string {
+	return q.queryName
+}
+
 // CaseInsensitive sets case insensitive matching of this query.
 func (q *WildcardQuery) CaseInsensitive(caseInsensitive bool) *WildcardQuery {
 	q.caseInsensitive = &caseInsensitive
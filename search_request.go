@@ -522,11 +522,7 @@ func (r *SearchRequest) header() interface{} {
 func (r *SearchRequest) Body() (string, error) {
 	if r.source == nil {
 		// Default: No custom source specified
-		src, err := r.searchSource.Source()
-		if err != nil {
-			return "", err
-		}
-		body, err := json.Marshal(src)
+		body, err := r.searchSource.SourceJSON()
 		if err != nil {
 			return "", err
 		}
@@ -0,0 +1,190 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import "errors"
+
+// Retriever returns the top documents of a search. It is an alternative
+// to the top-level query and knn parameters of a search, and allows
+// combining several retrievers, e.g. with reciprocal rank fusion.
+//
+// For more details, see:
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/retriever.html
+type Retriever interface {
+	// Source returns the JSON-serializable retriever, e.g.
+	// {"standard":{"query":{...}}}.
+	Source() (interface{}, error)
+}
+
+// -- Standard retriever --
+
+// StandardRetriever returns the top documents of a traditional query.
+type StandardRetriever struct {
+	query    Query
+	filter   []Query
+	minScore *float64
+}
+
+// NewStandardRetriever creates and initializes a new StandardRetriever.
+func NewStandardRetriever(query Query) *StandardRetriever {
+	return &StandardRetriever{
+		query: query,
+	}
+}
+
+// Filter adds queries that restrict the documents that can match.
+func (r *StandardRetriever) Filter(filters ...Query) *StandardRetriever {
+	r.filter = append(r.filter, filters...)
+	return r
+}
+
+// MinScore sets the minimum score of the documents to return.
+func (r *StandardRetriever) MinScore(minScore float64) *StandardRetriever {
+	r.minScore = &minScore
+	return r
+}
+
+// Source returns the JSON serializable content for this retriever.
+func (r *StandardRetriever) Source() (interface{}, error) {
+	// {
+	//   "standard": {
+	//     "query": { ... },
+	//     "filter": { ... },
+	//     "min_score": 1.0
+	//   }
+	// }
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["standard"] = params
+	if r.query != nil {
+		src, err := r.query.Source()
+		if err != nil {
+			return nil, err
+		}
+		params["query"] = src
+	}
+	if n := len(r.filter); n == 1 {
+		src, err := r.filter[0].Source()
+		if err != nil {
+			return nil, err
+		}
+		params["filter"] = src
+	} else if n > 1 {
+		var filters []interface{}
+		for _, f := range r.filter {
+			src, err := f.Source()
+			if err != nil {
+				return nil, err
+			}
+			filters = append(filters, src)
+		}
+		params["filter"] = filters
+	}
+	if r.minScore != nil {
+		params["min_score"] = *r.minScore
+	}
+	return source, nil
+}
+
+// -- kNN retriever --
+
+// KnnRetriever returns the top documents of a k-nearest neighbor search.
+type KnnRetriever struct {
+	knn *KnnQuery
+}
+
+// NewKnnRetriever creates and initializes a new KnnRetriever from knn.
+func NewKnnRetriever(knn *KnnQuery) *KnnRetriever {
+	return &KnnRetriever{
+		knn: knn,
+	}
+}
+
+// Source returns the JSON serializable content for this retriever.
+func (r *KnnRetriever) Source() (interface{}, error) {
+	// {
+	//   "knn": {
+	//     "field": "vector",
+	//     "query_vector": [10, 22, 77],
+	//     "k": 10,
+	//     "num_candidates": 10
+	//   }
+	// }
+	if r.knn == nil {
+		return nil, errors.New("elastic: knn retriever requires a knn query")
+	}
+	src, err := r.knn.Source()
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{"knn": src}, nil
+}
+
+// -- RRF retriever --
+
+// RRFRetriever combines the results of its child retrievers with
+// reciprocal rank fusion (RRF).
+type RRFRetriever struct {
+	retrievers     []Retriever
+	rankConstant   *int
+	rankWindowSize *int
+}
+
+// NewRRFRetriever creates and initializes a new RRFRetriever.
+func NewRRFRetriever(retrievers ...Retriever) *RRFRetriever {
+	return &RRFRetriever{
+		retrievers: retrievers,
+	}
+}
+
+// Retriever adds child retrievers.
+func (r *RRFRetriever) Retriever(retrievers ...Retriever) *RRFRetriever {
+	r.retrievers = append(r.retrievers, retrievers...)
+	return r
+}
+
+// RankConstant determines how much influence documents in individual
+// result sets have over the final ranking. It defaults to 60.
+func (r *RRFRetriever) RankConstant(rankConstant int) *RRFRetriever {
+	r.rankConstant = &rankConstant
+	return r
+}
+
+// RankWindowSize is the number of documents taken from each child
+// retriever. It defaults to the size of the search.
+func (r *RRFRetriever) RankWindowSize(rankWindowSize int) *RRFRetriever {
+	r.rankWindowSize = &rankWindowSize
+	return r
+}
+
+// Source returns the JSON serializable content for this retriever.
+func (r *RRFRetriever) Source() (interface{}, error) {
+	// {
+	//   "rrf": {
+	//     "retrievers": [ ... ],
+	//     "rank_constant": 60,
+	//     "rank_window_size": 100
+	//   }
+	// }
+	source := make(map[string]interface{})
+	params := make(map[string]interface{})
+	source["rrf"] = params
+	retrievers := make([]interface{}, 0, len(r.retrievers))
+	for _, retriever := range r.retrievers {
+		src, err := retriever.Source()
+		if err != nil {
+			return nil, err
+		}
+		retrievers = append(retrievers, src)
+	}
+	params["retrievers"] = retrievers
+	if r.rankConstant != nil {
+		params["rank_constant"] = *r.rankConstant
+	}
+	if r.rankWindowSize != nil {
+		params["rank_window_size"] = *r.rankWindowSize
+	}
+	return source, nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestStandardRetriever(t *testing.T) {
+	r := NewStandardRetriever(NewMatchQuery("text", "shoes")).
+		Filter(NewTermQuery("brand", "acme")).
+		MinScore(0.5)
+	src, err := r.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"standard":{"filter":{"term":{"brand":"acme"}},"min_score":0.5,"query":{"match":{"text":{"query":"shoes"}}}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchSourceRRFRetriever(t *testing.T) {
+	builder := NewSearchSource().Retriever(
+		NewRRFRetriever(
+			NewStandardRetriever(NewTermQuery("text", "shoes")),
+			NewKnnRetriever(NewKnnQuery("vector", []float32{1.25, 2, 3.5}, 50, 100)),
+		).RankConstant(60).RankWindowSize(100),
+	).Size(10)
+	if err := builder.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"retriever":{"rrf":{"rank_constant":60,"rank_window_size":100,"retrievers":[{"standard":{"query":{"term":{"text":"shoes"}}}},{"knn":{"field":"vector","k":50,"num_candidates":100,"query_vector":[1.25,2,3.5]}}]}},"size":10}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchServiceRetrieverWithQuery(t *testing.T) {
+	s := NewSearchService().
+		Query(NewMatchAllQuery()).
+		Retriever(NewStandardRetriever(NewTermQuery("text", "shoes")))
+	err := s.Validate()
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	if want, have := "elastic: retriever must not be combined with query or knn", err.Error(); want != have {
+		t.Fatalf("expected error %q; got: %q", want, have)
+	}
+}
+
+func TestKnnRetrieverWithoutQuery(t *testing.T) {
+	r := NewKnnRetriever(nil)
+	if _, err := r.Source(); err == nil {
+		t.Fatal("expected error when no knn query is set")
+	}
+}
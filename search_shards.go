@@ -5,7 +5,10 @@
 package elastic
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -27,6 +30,13 @@ type SearchShardsService struct {
 	expandWildcards   string
 }
 
+// NewSearchShardsService creates a new SearchShardsService.
+func NewSearchShardsService(indices ...string) *SearchShardsService {
+	return &SearchShardsService{
+		index: indices,
+	}
+}
+
 // Pretty tells Elasticsearch whether to return a formatted JSON response.
 func (s *SearchShardsService) Pretty(pretty bool) *SearchShardsService {
 	s.pretty = &pretty
@@ -114,6 +124,101 @@ func (s *SearchShardsService) ExpandWildcards(expandWildcards string) *SearchSha
 	return s
 }
 
+// buildURL builds the URL for the operation.
+func (s *SearchShardsService) buildURL() (string, url.Values, error) {
+	path := "/_search_shards"
+	if len(s.index) > 0 {
+		escaped := make([]string, len(s.index))
+		for i, index := range s.index {
+			escaped[i] = url.PathEscape(index)
+		}
+		path = "/" + strings.Join(escaped, ",") + path
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if v := s.pretty; v != nil {
+		params.Set("pretty", fmt.Sprint(*v))
+	}
+	if v := s.human; v != nil {
+		params.Set("human", fmt.Sprint(*v))
+	}
+	if v := s.errorTrace; v != nil {
+		params.Set("error_trace", fmt.Sprint(*v))
+	}
+	if len(s.filterPath) > 0 {
+		params.Set("filter_path", strings.Join(s.filterPath, ","))
+	}
+	if s.local != nil {
+		params.Set("local", fmt.Sprint(*s.local))
+	}
+	if s.routing != "" {
+		params.Set("routing", s.routing)
+	}
+	if s.preference != "" {
+		params.Set("preference", s.preference)
+	}
+	if s.allowNoIndices != nil {
+		params.Set("allow_no_indices", fmt.Sprint(*s.allowNoIndices))
+	}
+	if s.expandWildcards != "" {
+		params.Set("expand_wildcards", s.expandWildcards)
+	}
+	if s.ignoreUnavailable != nil {
+		params.Set("ignore_unavailable", fmt.Sprint(*s.ignoreUnavailable))
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *SearchShardsService) Validate() error {
+	var invalid []string
+	if len(s.index) < 1 {
+		invalid = append(invalid, "Index")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// SearchShardsResponse is the response of SearchShardsService.
+type SearchShardsResponse struct {
+	Nodes   map[string]*SearchShardsResponseNode  `json:"nodes"`
+	Indices map[string]*SearchShardsResponseIndex `json:"indices"`
+	Shards  [][]*SearchShardsResponseShardsInfo   `json:"shards"`
+}
+
+// SearchShardsResponseNode describes a node that holds one of the shards.
+type SearchShardsResponseNode struct {
+	Name             string            `json:"name"`
+	EphemeralId      string            `json:"ephemeral_id"`
+	TransportAddress string            `json:"transport_address"`
+	Attributes       map[string]string `json:"attributes,omitempty"`
+	Roles            []string          `json:"roles,omitempty"`
+}
+
+// SearchShardsResponseIndex describes an index the search would be
+// executed against, including alias filters if the index was resolved
+// via an alias.
+type SearchShardsResponseIndex struct {
+	Aliases []string               `json:"aliases,omitempty"`
+	Filter  map[string]interface{} `json:"filter,omitempty"`
+}
+
+// SearchShardsResponseShardsInfo describes a single shard copy.
+type SearchShardsResponseShardsInfo struct {
+	Index          string          `json:"index"`
+	Node           string          `json:"node"`
+	Primary        bool            `json:"primary"`
+	State          string          `json:"state"`
+	AllocationId   *AllocationId   `json:"allocation_id,omitempty"`
+	RelocatingNode string          `json:"relocating_node"`
+	Shard          int             `json:"shard"`
+	RecoverySource *RecoverySource `json:"recovery_source,omitempty"`
+	UnassignedInfo *UnassignedInfo `json:"unassigned_info,omitempty"`
+}
+
 type RecoverySource struct {
 	Type string `json:"type"`
 	// TODO add missing fields here based on the Type
@@ -4,6 +4,11 @@
 
 package elastic
 
+import (
+	"encoding/json"
+	"testing"
+)
+
 // import (
 // 	"context"
 // 	"testing"
@@ -33,3 +38,123 @@ package elastic
 // 		t.Fatal("expected to return STARTED status for running shards")
 // 	}
 // }
+
+func TestSearchShardsBuildURL(t *testing.T) {
+	tests := []struct {
+		Service *SearchShardsService
+		Path    string
+		Params  string
+	}{
+		{NewSearchShardsService("twitter"), "/twitter/_search_shards", ""},
+		{NewSearchShardsService("twitter", "facebook").Routing("user1").Local(true), "/twitter,facebook/_search_shards", "local=true&routing=user1"},
+		{NewSearchShardsService("logs-*").ExpandWildcards("open").IgnoreUnavailable(true), "/logs-%2A/_search_shards", "expand_wildcards=open&ignore_unavailable=true"},
+	}
+	for i, tt := range tests {
+		path, params, err := tt.Service.buildURL()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if want, have := tt.Path, path; want != have {
+			t.Errorf("#%d: want path %q, have %q", i, want, have)
+		}
+		if want, have := tt.Params, params.Encode(); want != have {
+			t.Errorf("#%d: want params %q, have %q", i, want, have)
+		}
+	}
+	if err := NewSearchShardsService().Validate(); err == nil {
+		t.Errorf("expected error for missing index")
+	}
+}
+
+func TestSearchShardsResponseDecode(t *testing.T) {
+	body := `{
+		"nodes": {
+			"JklnKbD7Tyqi9TP3_Q_tBg": {
+				"name": "node-0",
+				"ephemeral_id": "rGXp3c3mQoOGSbCO5nVp1A",
+				"transport_address": "127.0.0.1:9300",
+				"attributes": {"ml.max_open_jobs": "20"},
+				"roles": ["data", "master"]
+			}
+		},
+		"indices": {
+			"twitter": {"aliases": ["tweets"], "filter": {"term": {"user": "kimchy"}}}
+		},
+		"shards": [
+			[
+				{
+					"index": "twitter",
+					"node": "JklnKbD7Tyqi9TP3_Q_tBg",
+					"primary": true,
+					"shard": 0,
+					"state": "STARTED",
+					"allocation_id": {"id": "0TvkCyF7TAmM1wHP4a42-A"},
+					"relocating_node": null
+				}
+			],
+			[
+				{
+					"index": "twitter",
+					"node": null,
+					"primary": false,
+					"shard": 1,
+					"state": "UNASSIGNED",
+					"recovery_source": {"type": "PEER"},
+					"unassigned_info": {
+						"reason": "INDEX_CREATED",
+						"at": "2023-01-31T05:34:43.305Z",
+						"delayed": false,
+						"allocation_status": "no_attempt"
+					}
+				}
+			]
+		]
+	}`
+	var res SearchShardsResponse
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	node, found := res.Nodes["JklnKbD7Tyqi9TP3_Q_tBg"]
+	if !found || node == nil {
+		t.Fatalf("expected node to be found; got %v", res.Nodes)
+	}
+	if want, have := "node-0", node.Name; want != have {
+		t.Errorf("want node name %q, have %q", want, have)
+	}
+	if want, have := "127.0.0.1:9300", node.TransportAddress; want != have {
+		t.Errorf("want transport address %q, have %q", want, have)
+	}
+	index, found := res.Indices["twitter"]
+	if !found || index == nil {
+		t.Fatalf("expected index to be found; got %v", res.Indices)
+	}
+	if want, have := 1, len(index.Aliases); want != have {
+		t.Errorf("want %d aliases, have %d", want, have)
+	}
+	if want, have := 2, len(res.Shards); want != have {
+		t.Fatalf("want %d shards, have %d", want, have)
+	}
+	primary := res.Shards[0][0]
+	if want, have := "STARTED", primary.State; want != have {
+		t.Errorf("want state %q, have %q", want, have)
+	}
+	if !primary.Primary {
+		t.Errorf("expected primary shard")
+	}
+	if primary.AllocationId == nil || primary.AllocationId.Id != "0TvkCyF7TAmM1wHP4a42-A" {
+		t.Errorf("want allocation id %q, have %+v", "0TvkCyF7TAmM1wHP4a42-A", primary.AllocationId)
+	}
+	replica := res.Shards[1][0]
+	if want, have := "UNASSIGNED", replica.State; want != have {
+		t.Errorf("want state %q, have %q", want, have)
+	}
+	if replica.RecoverySource == nil || replica.RecoverySource.Type != "PEER" {
+		t.Errorf("want recovery source %q, have %+v", "PEER", replica.RecoverySource)
+	}
+	if replica.UnassignedInfo == nil || replica.UnassignedInfo.Reason != "INDEX_CREATED" {
+		t.Fatalf("want unassigned reason %q, have %+v", "INDEX_CREATED", replica.UnassignedInfo)
+	}
+	if replica.UnassignedInfo.At == nil {
+		t.Errorf("expected unassigned at to be set")
+	}
+}
@@ -6,7 +6,9 @@ package elastic
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 )
 
 // SearchSource enables users to build the search source.
@@ -29,9 +31,11 @@ type SearchSource struct {
 	terminateAfter           *int                   // terminate_after
 	storedFieldNames         []string               // stored_fields
 	docvalueFields           DocvalueFields         // docvalue_fields
+	fields                   FieldAndFormats        // fields
 	scriptFields             []*ScriptField         // script_fields
 	fetchSourceContext       *FetchSourceContext    // _source
 	aggregations             map[string]Aggregation // aggregations / aggs
+	duplicateAggregations    []string               // names passed to Aggregation more than once
 	highlight                *Highlight             // highlight
 	globalSuggestText        string
 	suggesters               []Suggester // suggest
@@ -45,6 +49,13 @@ type SearchSource struct {
 	// TODO extBuilders []SearchExtBuilder // ext
 	pointInTime     *PointInTime // pit
 	runtimeMappings RuntimeMappings
+	knn             []*KnnQuery // knn
+	retriever       Retriever   // retriever
+
+	frozen       bool                   // see FreezeSource
+	frozenSource map[string]interface{} // snapshot taken by FreezeSource
+	frozenJSON   []byte                 // frozenSource serialized as JSON
+	frozenErr    error                  // error serializing frozenSource
 }
 
 // NewSearchSource initializes a new SearchSource.
@@ -78,6 +89,18 @@ func (s *SearchSource) PostFilter(postFilter Query) *SearchSource {
 	return s
 }
 
+// ClearQuery removes a query previously set via Query.
+func (s *SearchSource) ClearQuery() *SearchSource {
+	s.query = nil
+	return s
+}
+
+// ClearPostFilter removes a post filter previously set via PostFilter.
+func (s *SearchSource) ClearPostFilter() *SearchSource {
+	s.postQuery = nil
+	return s
+}
+
 // Slice allows partitioning the documents in multiple slices.
 // It is e.g. used to slice a scroll operation, supported in
 // Elasticsearch 5.0 or later.
@@ -196,7 +219,12 @@ func (s *SearchSource) SearchAfter(sortValues ...interface{}) *SearchSource {
 }
 
 // Aggregation adds an aggreation to perform as part of the search.
+// Adding an aggregation with a name that is already in use replaces
+// the existing one and makes Validate fail.
 func (s *SearchSource) Aggregation(name string, aggregation Aggregation) *SearchSource {
+	if _, found := s.aggregations[name]; found {
+		s.duplicateAggregations = append(s.duplicateAggregations, name)
+	}
 	s.aggregations[name] = aggregation
 	return s
 }
@@ -275,6 +303,26 @@ func (s *SearchSource) FetchSourceIncludeExclude(include, exclude []string) *Sea
 	return s
 }
 
+// SourceIncludes specifies fields of _source to be returned with each hit.
+// It is a shortcut for setting the includes of a FetchSourceContext.
+func (s *SearchSource) SourceIncludes(fields ...string) *SearchSource {
+	if s.fetchSourceContext == nil {
+		s.fetchSourceContext = NewFetchSourceContext(true)
+	}
+	s.fetchSourceContext.Include(fields...)
+	return s
+}
+
+// SourceExcludes specifies fields of _source to be omitted from each hit.
+// It is a shortcut for setting the excludes of a FetchSourceContext.
+func (s *SearchSource) SourceExcludes(fields ...string) *SearchSource {
+	if s.fetchSourceContext == nil {
+		s.fetchSourceContext = NewFetchSourceContext(true)
+	}
+	s.fetchSourceContext.Exclude(fields...)
+	return s
+}
+
 // NoStoredFields indicates that no fields should be loaded, resulting in only
 // id and type to be returned per field.
 func (s *SearchSource) NoStoredFields() *SearchSource {
@@ -282,6 +330,14 @@ func (s *SearchSource) NoStoredFields() *SearchSource {
 	return s
 }
 
+// StoredFieldsNone disables loading of stored fields and metadata
+// fields like _id entirely by setting stored_fields to "_none_".
+// This is useful e.g. for requests that only return aggregations.
+func (s *SearchSource) StoredFieldsNone() *SearchSource {
+	s.storedFieldNames = []string{"_none_"}
+	return s
+}
+
 // StoredField adds a single field to load and return (note, must be stored) as
 // part of the search request. If none are specified, the source of the
 // document will be returned.
@@ -327,6 +383,20 @@ func (s *SearchSource) DocvalueFieldsWithFormat(docvalueFields ...DocvalueField)
 	return s
 }
 
+// Field adds a single field to retrieve via the fields API, formatted
+// according to format (which may be empty), e.g. a date format.
+func (s *SearchSource) Field(name, format string) *SearchSource {
+	s.fields = append(s.fields, FieldAndFormat{Field: name, Format: format})
+	return s
+}
+
+// Fields adds one or more fields to retrieve via the fields API.
+// The values are returned per hit in SearchHit.Fields.
+func (s *SearchSource) Fields(fields ...FieldAndFormat) *SearchSource {
+	s.fields = append(s.fields, fields...)
+	return s
+}
+
 // ScriptField adds a single script field with the provided script.
 func (s *SearchSource) ScriptField(scriptField *ScriptField) *SearchSource {
 	s.scriptFields = append(s.scriptFields, scriptField)
@@ -377,14 +447,142 @@ func (s *SearchSource) PointInTime(pointInTime *PointInTime) *SearchSource {
 	return s
 }
 
+// KNN adds one or more approximate k-nearest neighbor searches.
+// A single search is sent as an object, several ones as an array,
+// which requires Elasticsearch 8.4 or later. Nil queries are ignored.
+func (s *SearchSource) KNN(queries ...*KnnQuery) *SearchSource {
+	for _, q := range queries {
+		if q != nil {
+			s.knn = append(s.knn, q)
+		}
+	}
+	return s
+}
+
+// Retriever sets the retriever to return the top documents. It replaces
+// the top-level query and knn, which must not be set along with it.
+func (s *SearchSource) Retriever(retriever Retriever) *SearchSource {
+	s.retriever = retriever
+	return s
+}
+
 // RuntimeMappings specifies optional runtime mappings.
 func (s *SearchSource) RuntimeMappings(runtimeMappings RuntimeMappings) *SearchSource {
 	s.runtimeMappings = runtimeMappings
 	return s
 }
 
+// FreezeSource takes a snapshot of the search source and serializes it
+// once. From then on, Source and SourceJSON return the snapshot, which
+// avoids re-serializing a search source that is executed repeatedly,
+// e.g. when polling. It is safe to call Source and SourceJSON
+// concurrently on a frozen search source.
+//
+// A frozen search source is immutable: changes made via setters after
+// FreezeSource are not reflected in the output. Call FreezeSource again
+// to take a new snapshot. Notice that Source returns a shallow copy of the
+// snapshot, so nested values are shared with it and must not be modified.
+// An error serializing the snapshot is returned by Source and SourceJSON.
+func (s *SearchSource) FreezeSource() *SearchSource {
+	s.frozen = false
+	s.frozenSource, s.frozenJSON, s.frozenErr = nil, nil, nil
+	src, err := s.source()
+	if err == nil {
+		s.frozenJSON, err = json.Marshal(src)
+	}
+	s.frozenSource, s.frozenErr = src, err
+	s.frozen = true
+	return s
+}
+
+// Validate checks if the search source is valid.
+func (s *SearchSource) Validate() error {
+	if len(s.duplicateAggregations) > 0 {
+		return fmt.Errorf("elastic: duplicate aggregation names: %s", strings.Join(s.duplicateAggregations, ", "))
+	}
+	if err := s.docvalueFields.Validate(); err != nil {
+		return err
+	}
+	if s.retriever != nil && (s.query != nil || len(s.knn) > 0) {
+		return errors.New("elastic: retriever must not be combined with query or knn")
+	}
+	return nil
+}
+
+// validateRescoreWindow returns an error if the window of a rescore is
+// smaller than from+size, i.e. the rescore does not cover all hits that
+// are returned. Only window sizes that are set explicitly, per rescore or
+// via DefaultRescoreWindowSize, are checked; Elasticsearch accepts a
+// rescore without a window size and rescores the top 10 hits then.
+func (s *SearchSource) validateRescoreWindow() error {
+	from, size := s.from, s.size
+	if from < 0 {
+		from = 0
+	}
+	if size < 0 {
+		size = 10
+	}
+	for i, r := range s.rescores {
+		if r == nil || r.IsEmpty() {
+			continue
+		}
+		var window int
+		switch {
+		case r.windowSize != nil:
+			window = *r.windowSize
+		case s.defaultRescoreWindowSize != nil:
+			window = *s.defaultRescoreWindowSize
+		default:
+			continue
+		}
+		if window < from+size {
+			return fmt.Errorf("elastic: window size %d of rescore #%d is smaller than from+size (%d)", window, i, from+size)
+		}
+	}
+	return nil
+}
+
 // Source returns the serializable JSON for the source builder.
+// For a frozen search source, it returns a shallow copy of the snapshot
+// taken by FreezeSource.
 func (s *SearchSource) Source() (interface{}, error) {
+	if !s.frozen {
+		return s.source()
+	}
+	if s.frozenErr != nil {
+		return nil, s.frozenErr
+	}
+	source := make(map[string]interface{}, len(s.frozenSource))
+	for k, v := range s.frozenSource {
+		source[k] = v
+	}
+	return source, nil
+}
+
+// SourceJSON returns the search source serialized as JSON. For a frozen
+// search source, it returns a copy of the JSON serialized by FreezeSource.
+func (s *SearchSource) SourceJSON() ([]byte, error) {
+	if !s.frozen {
+		src, err := s.source()
+		if err != nil {
+			return nil, err
+		}
+		return json.Marshal(src)
+	}
+	if s.frozenErr != nil {
+		return nil, s.frozenErr
+	}
+	data := make([]byte, len(s.frozenJSON))
+	copy(data, s.frozenJSON)
+	return data, nil
+}
+
+// source returns the serializable JSON for the source builder.
+func (s *SearchSource) source() (map[string]interface{}, error) {
+	if s.from > 0 && len(s.searchAfterSortValues) > 0 {
+		return nil, errors.New("elastic: from must be 0 or unset when using search_after")
+	}
+
 	source := make(map[string]interface{})
 
 	if s.from != -1 {
@@ -447,6 +645,13 @@ func (s *SearchSource) Source() (interface{}, error) {
 		}
 		source["docvalue_fields"] = src
 	}
+	if len(s.fields) > 0 {
+		src, err := s.fields.Source()
+		if err != nil {
+			return nil, err
+		}
+		source["fields"] = src
+	}
 	if len(s.scriptFields) > 0 {
 		sfmap := make(map[string]interface{})
 		for _, scriptField := range s.scriptFields {
@@ -630,6 +835,34 @@ func (s *SearchSource) Source() (interface{}, error) {
 		source["runtime_mappings"] = src
 	}
 
+	switch len(s.knn) {
+	case 0:
+	case 1:
+		src, err := s.knn[0].Source()
+		if err != nil {
+			return nil, err
+		}
+		source["knn"] = src
+	default:
+		var knn []interface{}
+		for _, q := range s.knn {
+			src, err := q.Source()
+			if err != nil {
+				return nil, err
+			}
+			knn = append(knn, src)
+		}
+		source["knn"] = knn
+	}
+
+	if s.retriever != nil {
+		src, err := s.retriever.Source()
+		if err != nil {
+			return nil, err
+		}
+		source["retriever"] = src
+	}
+
 	return source, nil
 }
 
@@ -638,11 +871,7 @@ func (q *SearchSource) MarshalJSON() ([]byte, error) {
 	if q == nil {
 		return nilByte, nil
 	}
-	src, err := q.Source()
-	if err != nil {
-		return nil, err
-	}
-	return json.Marshal(src)
+	return q.SourceJSON()
 }
 
 // -- IndexBoosts --
@@ -6,6 +6,8 @@ package elastic
 
 import (
 	"encoding/json"
+	"io/ioutil"
+	"sync"
 	"testing"
 )
 
@@ -76,6 +78,40 @@ func TestSearchSourceStoredFields(t *testing.T) {
 	}
 }
 
+func TestSearchSourceStoredFieldsNone(t *testing.T) {
+	builder := NewSearchSource().Size(0).StoredFieldsNone()
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"size":0,"stored_fields":"_none_"}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchSourceStoredFieldsWildcard(t *testing.T) {
+	builder := NewSearchSource().Query(NewMatchAllQuery()).StoredFields("*")
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"query":{"match_all":{}},"stored_fields":"*"}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestSearchSourceFetchSourceDisabled(t *testing.T) {
 	matchAllQ := NewMatchAllQuery()
 	builder := NewSearchSource().Query(matchAllQ).FetchSource(false)
@@ -113,6 +149,59 @@ func TestSearchSourceFetchSourceByWildcards(t *testing.T) {
 	}
 }
 
+func TestSearchSourceFetchSourceFalseAfterIncludeExclude(t *testing.T) {
+	builder := NewSearchSource().Query(NewMatchAllQuery()).
+		FetchSourceIncludeExclude([]string{"obj.*"}, []string{"obj.secret"})
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"_source":{"excludes":["obj.secret"],"includes":["obj.*"]},"query":{"match_all":{}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+
+	// Disabling _source wins over any includes and excludes
+	builder = builder.FetchSource(false)
+	src, err = builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err = json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got = string(data)
+	expected = `{"_source":false,"query":{"match_all":{}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchSourceFields(t *testing.T) {
+	builder := NewSearchSource().Query(NewMatchAllQuery()).
+		Field("@timestamp", "yyyy-MM-dd").
+		Fields(FieldAndFormat{Field: "user.id"}, FieldAndFormat{Field: "created", Format: "epoch_millis"})
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"fields":[{"field":"@timestamp","format":"yyyy-MM-dd"},"user.id",{"field":"created","format":"epoch_millis"}],"query":{"match_all":{}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestSearchSourceDocvalueFields(t *testing.T) {
 	matchAllQ := NewMatchAllQuery()
 	builder := NewSearchSource().Query(matchAllQ).
@@ -217,6 +306,33 @@ func TestSearchSourceRescoring(t *testing.T) {
 	}
 }
 
+func TestSearchSourceMultipleRescorers(t *testing.T) {
+	matchAllQ := NewMatchAllQuery()
+	first := NewRescore().Rescorer(
+		NewQueryRescorer(NewMatchPhraseQuery("field1", "the quick brown fox").Slop(2)).QueryWeight(0.7),
+	)
+	second := NewRescore().WindowSize(10).Rescorer(
+		NewQueryRescorer(NewTermQuery("tags", "golang")).ScoreMode("multiply"),
+	)
+	builder := NewSearchSource().Query(matchAllQ).
+		DefaultRescoreWindowSize(100).
+		Rescorer(first).
+		Rescorer(second)
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"query":{"match_all":{}},"rescore":[{"query":{"query_weight":0.7,"rescore_query":{"match_phrase":{"field1":{"query":"the quick brown fox","slop":2}}}},"window_size":100},{"query":{"rescore_query":{"term":{"tags":"golang"}},"score_mode":"multiply"},"window_size":10}]}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestSearchSourceIndexBoost(t *testing.T) {
 	matchAllQ := NewMatchAllQuery()
 	builder := NewSearchSource().Query(matchAllQ).IndexBoost("index1", 1.4).IndexBoost("index2", 1.3)
@@ -371,3 +487,237 @@ func TestSearchSourceRuntimeMappings(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestSearchSourceStats(t *testing.T) {
+	builder := NewSearchSource().Query(NewMatchAllQuery()).Stats("groupA", "groupB")
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"query":{"match_all":{}},"stats":["groupA","groupB"]}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchSourceFreezeSource(t *testing.T) {
+	builder := NewSearchSource().Query(NewTermQuery("user", "olivere")).FreezeSource()
+	src1, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := src1.(map[string]interface{}); !ok {
+		t.Fatalf("expected frozen source to be a map; got: %T", src1)
+	}
+	data, err := json.Marshal(src1)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	if want, have := `{"query":{"term":{"user":"olivere"}}}`, string(data); want != have {
+		t.Errorf("expected\n%s\n,got:\n%s", want, have)
+	}
+
+	// Modifying the returned map or bytes does not change the snapshot
+	src1.(map[string]interface{})["size"] = 1
+	raw, err := builder.SourceJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw[0] = '['
+	data, err = builder.MarshalJSON()
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	if want, have := `{"query":{"term":{"user":"olivere"}}}`, string(data); want != have {
+		t.Errorf("expected\n%s\n,got:\n%s", want, have)
+	}
+
+	// A frozen source is immutable until frozen again
+	builder = builder.Size(5)
+	data, err = builder.SourceJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := `{"query":{"term":{"user":"olivere"}}}`, string(data); want != have {
+		t.Errorf("expected\n%s\n,got:\n%s", want, have)
+	}
+	data, err = builder.FreezeSource().SourceJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := `{"query":{"term":{"user":"olivere"}},"size":5}`, string(data); want != have {
+		t.Errorf("expected\n%s\n,got:\n%s", want, have)
+	}
+
+	// Errors are reported by Source
+	builder = NewSearchSource().SearchAfter("tweet#1").From(10).FreezeSource()
+	if _, err := builder.Source(); err == nil {
+		t.Fatal("expected error when combining from and search_after")
+	}
+}
+
+func TestSearchServiceWithFrozenSource(t *testing.T) {
+	ss := NewSearchSource().Query(NewTermQuery("user", "olivere")).FreezeSource()
+	req, err := NewSearchService().SearchSource(ss).buildRequest("http://127.0.0.1:9200")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := `{"query":{"term":{"user":"olivere"}}}`, string(data); want != have {
+		t.Errorf("expected\n%s\n,got:\n%s", want, have)
+	}
+}
+
+func TestInnerHitWithFrozenSource(t *testing.T) {
+	hit := NewInnerHit().Name("comments").Size(3)
+	hit.source.FreezeSource()
+	src, err := hit.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	if want, have := `{"name":"comments","size":3}`, string(data); want != have {
+		t.Errorf("expected\n%s\n,got:\n%s", want, have)
+	}
+}
+
+func TestSearchSourceDuplicateAggregation(t *testing.T) {
+	builder := NewSearchSource().
+		Aggregation("by_user", NewTermsAggregation().Field("user")).
+		Aggregation("by_tag", NewTermsAggregation().Field("tags"))
+	if err := builder.Validate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	builder = builder.Aggregation("by_user", NewTermsAggregation().Field("author"))
+	err := builder.Validate()
+	if err == nil {
+		t.Fatal("expected error for duplicate aggregation name")
+	}
+	if want, have := "elastic: duplicate aggregation names: by_user", err.Error(); want != have {
+		t.Errorf("expected error %q, got %q", want, have)
+	}
+	if err := NewSearchService().SearchSource(builder).Validate(); err == nil {
+		t.Fatal("expected SearchService.Validate to report duplicate aggregation name")
+	}
+}
+
+func TestSearchSourceDocvalueFieldWithFormat(t *testing.T) {
+	builder := NewSearchSource().Query(NewMatchAllQuery()).
+		DocvalueFieldWithFormat(DocvalueField{Field: "created", Format: "yyyy-MM-dd"})
+	if err := builder.Validate(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"docvalue_fields":[{"field":"created","format":"yyyy-MM-dd"}],"query":{"match_all":{}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+
+	builder = builder.DocvalueFieldWithFormat(DocvalueField{Format: "epoch_millis"})
+	if err := builder.Validate(); err == nil {
+		t.Fatal("expected error for docvalue field without a name")
+	}
+}
+
+func TestSearchSourceClearQueryAndPostFilter(t *testing.T) {
+	builder := NewSearchSource().
+		Query(NewTermQuery("user", "olivere")).
+		PostFilter(NewTermQuery("tags", "golang"))
+	builder = builder.ClearQuery().ClearPostFilter()
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchSourceFreezeSourceConcurrently(t *testing.T) {
+	builder := NewSearchSource().Query(NewTermQuery("user", "olivere")).FreezeSource()
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				if _, err := builder.Source(); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func TestSearchSourceClearPostFilterKeepsQuery(t *testing.T) {
+	builder := NewSearchSource().
+		Query(NewTermQuery("user", "olivere")).
+		PostFilter(NewTermQuery("tags", "golang"))
+	if _, err := builder.Source(); err != nil {
+		t.Fatal(err)
+	}
+	src, err := builder.ClearPostFilter().Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"query":{"term":{"user":"olivere"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchSourceFromWithSearchAfter(t *testing.T) {
+	builder := NewSearchSource().Query(NewMatchAllQuery()).
+		SearchAfter(1463538857, "tweet#654323").From(10)
+	if _, err := builder.Source(); err == nil {
+		t.Fatal("expected error when combining from and search_after")
+	}
+
+	builder = builder.From(0)
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"from":0,"query":{"match_all":{}},"search_after":[1463538857,"tweet#654323"]}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
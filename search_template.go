@@ -0,0 +1,217 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// SearchTemplateService executes a search with a mustache template, either
+// stored on the server (see Id) or given inline (see Source). With
+// RenderOnly, the template is only rendered via the render template API,
+// which returns a RenderTemplateResponse instead of a SearchResult.
+//
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/search-template.html
+// for details.
+type SearchTemplateService struct {
+	pretty     *bool    // pretty format the returned JSON response
+	human      *bool    // return human readable values for statistics
+	errorTrace *bool    // include the stack trace of returned errors
+	filterPath []string // list of filters used to reduce the response
+
+	index      []string
+	id         string
+	source     interface{}
+	params     map[string]interface{}
+	renderOnly bool
+	explain    *bool
+	profile    *bool
+	routing    string
+	preference string
+}
+
+// NewSearchTemplateService creates a new SearchTemplateService.
+func NewSearchTemplateService(indices ...string) *SearchTemplateService {
+	return &SearchTemplateService{
+		index: indices,
+	}
+}
+
+// Pretty tells Elasticsearch whether to return a formatted JSON response.
+func (s *SearchTemplateService) Pretty(pretty bool) *SearchTemplateService {
+	s.pretty = &pretty
+	return s
+}
+
+// Human specifies whether human readable values should be returned in
+// the JSON response, e.g. "7.5mb".
+func (s *SearchTemplateService) Human(human bool) *SearchTemplateService {
+	s.human = &human
+	return s
+}
+
+// ErrorTrace specifies whether to include the stack trace of returned errors.
+func (s *SearchTemplateService) ErrorTrace(errorTrace bool) *SearchTemplateService {
+	s.errorTrace = &errorTrace
+	return s
+}
+
+// FilterPath specifies a list of filters used to reduce the response.
+func (s *SearchTemplateService) FilterPath(filterPath ...string) *SearchTemplateService {
+	s.filterPath = filterPath
+	return s
+}
+
+// Index sets the names of the indices to search.
+func (s *SearchTemplateService) Index(index ...string) *SearchTemplateService {
+	s.index = append(s.index, index...)
+	return s
+}
+
+// Id is the ID of a stored search template.
+func (s *SearchTemplateService) Id(id string) *SearchTemplateService {
+	s.id = id
+	return s
+}
+
+// Source is an inline template, either as a string or as a
+// JSON-serializable value like a map.
+func (s *SearchTemplateService) Source(source interface{}) *SearchTemplateService {
+	s.source = source
+	return s
+}
+
+// Param sets a single template parameter.
+func (s *SearchTemplateService) Param(name string, value interface{}) *SearchTemplateService {
+	if s.params == nil {
+		s.params = make(map[string]interface{})
+	}
+	s.params[name] = value
+	return s
+}
+
+// Params sets the template parameters.
+func (s *SearchTemplateService) Params(params map[string]interface{}) *SearchTemplateService {
+	s.params = params
+	return s
+}
+
+// RenderOnly, if true, only renders the template via the render template
+// API instead of executing the search.
+func (s *SearchTemplateService) RenderOnly(renderOnly bool) *SearchTemplateService {
+	s.renderOnly = renderOnly
+	return s
+}
+
+// Explain indicates whether to return detailed information about score
+// computation as part of each hit.
+func (s *SearchTemplateService) Explain(explain bool) *SearchTemplateService {
+	s.explain = &explain
+	return s
+}
+
+// Profile indicates whether to profile the query execution.
+func (s *SearchTemplateService) Profile(profile bool) *SearchTemplateService {
+	s.profile = &profile
+	return s
+}
+
+// Routing is a list of specific routing values to control the shards
+// the search will be executed on.
+func (s *SearchTemplateService) Routing(routing string) *SearchTemplateService {
+	s.routing = routing
+	return s
+}
+
+// Preference specifies the node or shard the operation should be
+// performed on (default: random).
+func (s *SearchTemplateService) Preference(preference string) *SearchTemplateService {
+	s.preference = preference
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *SearchTemplateService) buildURL() (string, url.Values, error) {
+	var path string
+	if s.renderOnly {
+		path = "/_render/template"
+	} else {
+		path = "/_search/template"
+		if len(s.index) > 0 {
+			escaped := make([]string, len(s.index))
+			for i, index := range s.index {
+				escaped[i] = url.PathEscape(index)
+			}
+			path = "/" + strings.Join(escaped, ",") + path
+		}
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if v := s.pretty; v != nil {
+		params.Set("pretty", fmt.Sprint(*v))
+	}
+	if v := s.human; v != nil {
+		params.Set("human", fmt.Sprint(*v))
+	}
+	if v := s.errorTrace; v != nil {
+		params.Set("error_trace", fmt.Sprint(*v))
+	}
+	if len(s.filterPath) > 0 {
+		params.Set("filter_path", strings.Join(s.filterPath, ","))
+	}
+	if !s.renderOnly {
+		if s.routing != "" {
+			params.Set("routing", s.routing)
+		}
+		if s.preference != "" {
+			params.Set("preference", s.preference)
+		}
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *SearchTemplateService) Validate() error {
+	if s.id == "" && s.source == nil {
+		return errors.New("elastic: search template needs either Id or Source")
+	}
+	if s.id != "" && s.source != nil {
+		return errors.New("elastic: search template must not have both Id and Source")
+	}
+	return nil
+}
+
+// Body returns the request body.
+func (s *SearchTemplateService) Body() (interface{}, error) {
+	body := make(map[string]interface{})
+	if s.id != "" {
+		body["id"] = s.id
+	}
+	if s.source != nil {
+		body["source"] = s.source
+	}
+	if len(s.params) > 0 {
+		body["params"] = s.params
+	}
+	if !s.renderOnly {
+		if v := s.explain; v != nil {
+			body["explain"] = *v
+		}
+		if v := s.profile; v != nil {
+			body["profile"] = *v
+		}
+	}
+	return body, nil
+}
+
+// RenderTemplateResponse is the response of a SearchTemplateService with
+// RenderOnly set. A search template that is executed returns a SearchResult.
+type RenderTemplateResponse struct {
+	TemplateOutput map[string]interface{} `json:"template_output"`
+}
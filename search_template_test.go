@@ -0,0 +1,142 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSearchTemplateBuildURL(t *testing.T) {
+	tests := []struct {
+		Service *SearchTemplateService
+		Path    string
+		Params  string
+	}{
+		{NewSearchTemplateService(), "/_search/template", ""},
+		{NewSearchTemplateService("twitter", "facebook").Routing("user1"), "/twitter,facebook/_search/template", "routing=user1"},
+		{NewSearchTemplateService("twitter").RenderOnly(true).Routing("user1"), "/_render/template", ""},
+	}
+	for i, tt := range tests {
+		path, params, err := tt.Service.buildURL()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if want, have := tt.Path, path; want != have {
+			t.Errorf("#%d: want path %q, have %q", i, want, have)
+		}
+		if want, have := tt.Params, params.Encode(); want != have {
+			t.Errorf("#%d: want params %q, have %q", i, want, have)
+		}
+	}
+}
+
+func TestSearchTemplateValidate(t *testing.T) {
+	if err := NewSearchTemplateService().Validate(); err == nil {
+		t.Error("expected error without Id and Source")
+	}
+	if err := NewSearchTemplateService().Id("my-template").Source(`{}`).Validate(); err == nil {
+		t.Error("expected error with both Id and Source")
+	}
+	if err := NewSearchTemplateService().Id("my-template").Validate(); err != nil {
+		t.Errorf("expected no error; got: %v", err)
+	}
+}
+
+func TestSearchTemplateBody(t *testing.T) {
+	tests := []struct {
+		Service  *SearchTemplateService
+		Expected string
+	}{
+		{
+			NewSearchTemplateService("twitter").Id("my-template").Param("query_string", "hello world").Explain(true),
+			`{"explain":true,"id":"my-template","params":{"query_string":"hello world"}}`,
+		},
+		{
+			NewSearchTemplateService().
+				Source(map[string]interface{}{"query": map[string]interface{}{"match": map[string]interface{}{"message": "{{query_string}}"}}}).
+				Params(map[string]interface{}{"query_string": "hello world"}).
+				Explain(true).
+				RenderOnly(true),
+			`{"params":{"query_string":"hello world"},"source":{"query":{"match":{"message":"{{query_string}}"}}}}`,
+		},
+	}
+	for i, tt := range tests {
+		src, err := tt.Service.Body()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatalf("#%d: marshaling to JSON failed: %v", i, err)
+		}
+		if want, have := tt.Expected, string(data); want != have {
+			t.Errorf("#%d: expected\n%s\n,got:\n%s", i, want, have)
+		}
+	}
+}
+
+func TestRenderTemplateResponse(t *testing.T) {
+	body := `{"template_output":{"query":{"match":{"message":"hello world"}}}}`
+	var res RenderTemplateResponse
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(res.TemplateOutput)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	if want, have := `{"query":{"match":{"message":"hello world"}}}`, string(data); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestSearchTemplateExecute(t *testing.T) {
+	s := NewSearchTemplateService("twitter").
+		Id("my-template").
+		Param("query_string", "hello world").
+		Explain(true).
+		Profile(true).
+		Preference("_local")
+
+	path, params, err := s.buildURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "/twitter/_search/template", path; want != have {
+		t.Errorf("want path %q, have %q", want, have)
+	}
+	if want, have := "preference=_local", params.Encode(); want != have {
+		t.Errorf("want params %q, have %q", want, have)
+	}
+
+	src, err := s.Body()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	expected := `{"explain":true,"id":"my-template","params":{"query_string":"hello world"},"profile":true}`
+	if want, have := expected, string(data); want != have {
+		t.Errorf("expected\n%s\n,got:\n%s", want, have)
+	}
+
+	body := `{"took":2,"timed_out":false,"hits":{"total":{"value":1,"relation":"eq"},"hits":[{"_index":"twitter","_id":"1","_score":1.0,"_explanation":{"value":1.0,"description":"weight(message:hello)"}}]},"profile":{"shards":[]}}`
+	var res SearchResult
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := int64(1), res.TotalHits(); want != have {
+		t.Errorf("want %d hits, have %d", want, have)
+	}
+	if res.Hits.Hits[0].Explanation == nil {
+		t.Error("expected explanation of hit")
+	}
+	if res.Profile == nil {
+		t.Error("expected profile")
+	}
+}
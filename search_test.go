@@ -0,0 +1,1010 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSearchServiceStats(t *testing.T) {
+	s := NewSearchService().Query(NewMatchAllQuery()).Stats("groupA").Stats("groupB")
+	src, err := s.searchSource.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"query":{"match_all":{}},"stats":["groupA","groupB"]}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchServiceSearchType(t *testing.T) {
+	s := NewSearchService().SearchType("dfs_query_then_fetch")
+	if err := s.Validate(); err != nil {
+		t.Fatalf("expected no validation error; got: %v", err)
+	}
+	path, params, err := s.buildURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "/_search", path; want != have {
+		t.Fatalf("expected path %q; got: %q", want, have)
+	}
+	if want, have := "search_type=dfs_query_then_fetch", params.Encode(); want != have {
+		t.Fatalf("expected params %q; got: %q", want, have)
+	}
+}
+
+func TestSearchServiceSearchTypeInvalid(t *testing.T) {
+	s := NewSearchService().SearchType("query_and_fetch")
+	if err := s.Validate(); err == nil {
+		t.Fatal("expected validation error for unknown search type")
+	}
+	_, err := s.buildRequest("http://127.0.0.1:9200")
+	if err == nil {
+		t.Fatal("expected buildRequest to reject unknown search type")
+	}
+	if want, have := `elastic: invalid search type "query_and_fetch"`, err.Error(); want != have {
+		t.Fatalf("expected error %q; got: %q", want, have)
+	}
+}
+
+func TestSearchServiceDerivativeOfDateHistogram(t *testing.T) {
+	h := NewDateHistogramAggregation().Field("date").CalendarInterval("month")
+	h = h.SubAggregation("sales", NewSumAggregation().Field("price"))
+	h = h.SubAggregation("sales_deriv", NewDerivativeAggregation().BucketsPath("sales").Unit("day"))
+
+	s := NewSearchService().Size(0).Aggregation("sales_per_month", h)
+	src, err := s.searchSource.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"aggregations":{"sales_per_month":{"aggregations":{"sales":{"sum":{"field":"price"}},"sales_deriv":{"derivative":{"buckets_path":"sales","unit":"day"}}},"date_histogram":{"calendar_interval":"month","field":"date"}}},"size":0}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchServiceBuildURL(t *testing.T) {
+	tests := []struct {
+		Service  *SearchService
+		Expected string
+	}{
+		{
+			NewSearchService(),
+			"",
+		},
+		{
+			NewSearchService().BatchedReduceSize(64),
+			"batched_reduce_size=64",
+		},
+		{
+			NewSearchService().MaxConcurrentShardRequests(3),
+			"max_concurrent_shard_requests=3",
+		},
+		{
+			NewSearchService().BatchedReduceSize(512).MaxConcurrentShardRequests(5),
+			"batched_reduce_size=512&max_concurrent_shard_requests=5",
+		},
+		{
+			NewSearchService().IgnoreUnavailable(true),
+			"ignore_unavailable=true",
+		},
+		{
+			NewSearchService().AllowNoIndices(false),
+			"allow_no_indices=false",
+		},
+		{
+			NewSearchService().ExpandWildcards("open,hidden"),
+			"expand_wildcards=open%2Chidden",
+		},
+		{
+			NewSearchService().IgnoreUnavailable(true).AllowNoIndices(true).ExpandWildcards("all"),
+			"allow_no_indices=true&expand_wildcards=all&ignore_unavailable=true",
+		},
+		{
+			NewSearchService().Pretty(true),
+			"pretty=true",
+		},
+		{
+			NewSearchService().Human(true),
+			"human=true",
+		},
+		{
+			NewSearchService().ErrorTrace(true),
+			"error_trace=true",
+		},
+		{
+			NewSearchService().FilterPath("took", "hits.hits._id"),
+			"filter_path=took%2Chits.hits._id",
+		},
+		{
+			NewSearchService().Pretty(false).Human(true).ErrorTrace(true).FilterPath("hits.total"),
+			"error_trace=true&filter_path=hits.total&human=true&pretty=false",
+		},
+	}
+
+	for i, tt := range tests {
+		_, params, err := tt.Service.buildURL()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if want, have := tt.Expected, params.Encode(); want != have {
+			t.Errorf("#%d: expected params %q; got: %q", i, want, have)
+		}
+	}
+}
+
+func TestSearchProfileShardTotals(t *testing.T) {
+	s := `{
+	"took": 25,
+	"timed_out": false,
+	"hits": { "total": { "value": 1, "relation": "eq" }, "max_score": 1.0, "hits": [] },
+	"profile": {
+		"shards": [
+			{
+				"id": "[2aE02wS1R8q_QFnYu6vDVQ][my-index][0]",
+				"searches": [
+					{
+						"query": [
+							{
+								"type": "BooleanQuery",
+								"description": "message:get message:search",
+								"time_in_nanos": 11972972,
+								"breakdown": { "score": 51306, "create_weight": 4694895 },
+								"children": [
+									{ "type": "TermQuery", "description": "message:get", "time_in_nanos": 3801935 },
+									{ "type": "TermQuery", "description": "message:search", "time_in_nanos": 205654 }
+								]
+							}
+						],
+						"rewrite_time": 51443,
+						"collector": [
+							{
+								"name": "SimpleTopScoreDocCollector",
+								"reason": "search_top_hits",
+								"time_in_nanos": 32273,
+								"children": [
+									{ "name": "Inner", "reason": "search_inner", "time_in_nanos": 1000 }
+								]
+							}
+						]
+					}
+				],
+				"aggregations": []
+			},
+			{
+				"id": "[2aE02wS1R8q_QFnYu6vDVQ][my-index][1]",
+				"searches": [
+					{
+						"query": [
+							{ "type": "TermQuery", "description": "message:get", "time_in_nanos": 1000 },
+							{ "type": "TermQuery", "description": "message:search", "time_in_nanos": 500 }
+						],
+						"rewrite_time": 20,
+						"collector": [
+							{ "name": "SimpleTopScoreDocCollector", "reason": "search_top_hits", "time_in_nanos": 300 }
+						]
+					},
+					{
+						"query": [
+							{ "type": "MatchAllDocsQuery", "description": "*:*", "time_in_nanos": 100 }
+						],
+						"rewrite_time": 5,
+						"collector": [
+							{ "name": "TotalHitCountCollector", "reason": "search_count", "time_in_nanos": 50 }
+						]
+					}
+				],
+				"aggregations": []
+			}
+		]
+	}
+}`
+
+	var res SearchResult
+	if err := json.Unmarshal([]byte(s), &res); err != nil {
+		t.Fatalf("expected no error decoding; got: %v", err)
+	}
+	totals := res.Profile.ShardTotals()
+	expected := []SearchProfileShardTotals{
+		{
+			ID:             "[2aE02wS1R8q_QFnYu6vDVQ][my-index][0]",
+			QueryNanos:     11972972,
+			RewriteNanos:   51443,
+			CollectorNanos: 32273,
+		},
+		{
+			ID:             "[2aE02wS1R8q_QFnYu6vDVQ][my-index][1]",
+			QueryNanos:     1600,
+			RewriteNanos:   25,
+			CollectorNanos: 350,
+		},
+	}
+	if !reflect.DeepEqual(expected, totals) {
+		t.Fatalf("expected\n%+v\n,got:\n%+v", expected, totals)
+	}
+
+	var nilProfile *SearchProfile
+	if totals := nilProfile.ShardTotals(); totals != nil {
+		t.Fatalf("expected nil totals for nil profile; got: %+v", totals)
+	}
+}
+
+func TestSearchServiceStoredFieldsNone(t *testing.T) {
+	s := NewSearchService().StoredFields("message").StoredFieldsNone()
+	src, err := s.searchSource.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"stored_fields":"_none_"}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchHitInnerHitTotalAndMaxScore(t *testing.T) {
+	body := `{
+		"_index": "test",
+		"_id": "1",
+		"_score": 1.0,
+		"inner_hits": {
+			"comments": {
+				"hits": {
+					"total": {"value": 3, "relation": "eq"},
+					"max_score": 1.2,
+					"hits": [
+						{"_index": "test", "_id": "1", "_nested": {"field": "comments", "offset": 0}, "_score": 1.2}
+					]
+				}
+			}
+		}
+	}`
+	var hit SearchHit
+	if err := json.Unmarshal([]byte(body), &hit); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := int64(3), hit.InnerHitTotal("comments"); want != have {
+		t.Errorf("expected total of %d, got %d", want, have)
+	}
+	if score := hit.InnerHitMaxScore("comments"); score == nil || *score != 1.2 {
+		t.Errorf("expected max score of %v, got %v", 1.2, score)
+	}
+	if want, have := int64(0), hit.InnerHitTotal("missing"); want != have {
+		t.Errorf("expected total of %d, got %d", want, have)
+	}
+	if score := hit.InnerHitMaxScore("missing"); score != nil {
+		t.Errorf("expected no max score, got %v", *score)
+	}
+}
+
+func TestSearchServiceCompress(t *testing.T) {
+	ids := make([]interface{}, 10000)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("id-%d", i)
+	}
+	query := NewTermsQuery("_id", ids...)
+
+	tests := []struct {
+		Service  *SearchService
+		Encoding string
+	}{
+		{NewSearchService().Query(query), ""},
+		{NewSearchService().Query(query).Compress(false), ""},
+		{NewSearchService().Query(query).Compress(true), "gzip"},
+	}
+	for i, tt := range tests {
+		req, err := tt.Service.buildRequest("http://127.0.0.1:9200")
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if want, have := tt.Encoding, req.Header.Get("Content-Encoding"); want != have {
+			t.Errorf("#%d: expected Content-Encoding %q, got %q", i, want, have)
+		}
+		if tt.Encoding != "gzip" {
+			continue
+		}
+		r, err := gzip.NewReader(req.Body)
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r).Decode(&body); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if _, ok := body["query"]; !ok {
+			t.Errorf("#%d: expected query in decompressed body, got %v", i, body)
+		}
+	}
+}
+
+func TestSearchServiceBuildRequestValidates(t *testing.T) {
+	s := NewSearchService().
+		Aggregation("users", NewTermsAggregation().Field("user")).
+		Aggregation("users", NewTermsAggregation().Field("user.keyword"))
+	if _, err := s.buildRequest("http://127.0.0.1:9200"); err == nil {
+		t.Fatal("expected error for duplicate aggregation names")
+	}
+	if _, err := NewSearchService().Query(NewMatchAllQuery()).buildRequest("http://127.0.0.1:9200"); err != nil {
+		t.Fatalf("expected no error; got: %v", err)
+	}
+}
+
+func TestSearchResultDidTimeout(t *testing.T) {
+	tests := []struct {
+		Body string
+		Want bool
+	}{
+		{`{"took":1,"timed_out":false,"hits":{"hits":[]}}`, false},
+		{`{"took":1,"timed_out":true,"hits":{"hits":[]}}`, true},
+		{`{"took":1}`, false},
+	}
+	for i, tt := range tests {
+		var res SearchResult
+		if err := json.Unmarshal([]byte(tt.Body), &res); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if want, have := tt.Want, res.DidTimeout(); want != have {
+			t.Errorf("#%d: want %v, have %v", i, want, have)
+		}
+	}
+	var res *SearchResult
+	if res.DidTimeout() {
+		t.Errorf("expected nil result not to time out")
+	}
+}
+
+func TestSearchServiceClearQuery(t *testing.T) {
+	s := NewSearchService().
+		Query(NewMatchAllQuery()).
+		PostFilter(NewTermQuery("user", "olivere")).
+		ClearQuery()
+	src, err := s.searchSource.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := `{"post_filter":{"term":{"user":"olivere"}}}`, string(data); want != have {
+		t.Errorf("want %s, have %s", want, have)
+	}
+}
+
+func TestSearchServiceTimeoutContext(t *testing.T) {
+	tests := []struct {
+		Service  *SearchService
+		Deadline time.Duration // 0 means no deadline
+	}{
+		{NewSearchService(), 0},
+		{NewSearchService().Timeout("2s"), 3 * time.Second},
+		{NewSearchService().Timeout("2s").TimeoutMargin(500 * time.Millisecond), 2500 * time.Millisecond},
+		{NewSearchService().TimeoutInMillis(1500), 2500 * time.Millisecond},
+		{NewSearchService().Timeout("1m").TimeoutMargin(0), time.Minute},
+		{NewSearchService().Timeout("invalid"), 0},
+	}
+	for i, tt := range tests {
+		start := time.Now()
+		ctx, cancel := tt.Service.TimeoutContext(context.Background())
+		deadline, ok := ctx.Deadline()
+		cancel()
+		if tt.Deadline == 0 {
+			if ok {
+				t.Errorf("#%d: expected no deadline, got %v", i, deadline)
+			}
+			continue
+		}
+		if !ok {
+			t.Fatalf("#%d: expected deadline", i)
+		}
+		if want, have := tt.Deadline, deadline.Sub(start); have < want || have > want+time.Second {
+			t.Errorf("#%d: expected deadline in %v, got %v", i, want, have)
+		}
+	}
+}
+
+func TestSearchServiceTimeoutContextKeepsEarlierDeadline(t *testing.T) {
+	parent, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	want, _ := parent.Deadline()
+
+	ctx, cancel2 := NewSearchService().Timeout("10s").TimeoutContext(parent)
+	defer cancel2()
+	have, ok := ctx.Deadline()
+	if !ok {
+		t.Fatal("expected deadline")
+	}
+	if !have.Equal(want) {
+		t.Errorf("expected deadline %v, got %v", want, have)
+	}
+}
+
+func TestParseTimeValue(t *testing.T) {
+	tests := []struct {
+		Value string
+		Want  time.Duration
+		Err   bool
+	}{
+		{"", 0, false},
+		{"1s", time.Second, false},
+		{"500ms", 500 * time.Millisecond, false},
+		{"2m", 2 * time.Minute, false},
+		{"1h", time.Hour, false},
+		{"1d", 24 * time.Hour, false},
+		{"10micros", 10 * time.Microsecond, false},
+		{"10nanos", 10 * time.Nanosecond, false},
+		{"1", 0, true},
+		{"1.5s", 0, true},
+		{"abc", 0, true},
+	}
+	for i, tt := range tests {
+		have, err := parseTimeValue(tt.Value)
+		if tt.Err {
+			if err == nil {
+				t.Errorf("#%d: expected error for %q", i, tt.Value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if have != tt.Want {
+			t.Errorf("#%d: want %v, have %v", i, tt.Want, have)
+		}
+	}
+}
+
+func TestSearchResultHasProfile(t *testing.T) {
+	tests := []struct {
+		Body string
+		Want bool
+	}{
+		{`{"took":1,"hits":{"hits":[]}}`, false},
+		{`{"took":1,"hits":{"hits":[]},"profile":{"shards":[{"id":"[node][index][0]","searches":[]}]}}`, true},
+	}
+	for i, tt := range tests {
+		var res SearchResult
+		if err := json.Unmarshal([]byte(tt.Body), &res); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if want, have := tt.Want, res.HasProfile(); want != have {
+			t.Errorf("#%d: want %v, have %v", i, want, have)
+		}
+	}
+	var res *SearchResult
+	if res.HasProfile() {
+		t.Errorf("expected nil result not to have a profile")
+	}
+}
+
+func TestSearchHitDataStream(t *testing.T) {
+	tests := []struct {
+		Index string
+		Name  string
+		Found bool
+	}{
+		{".ds-logs-nginx-2099.03.07-000001", "logs-nginx", true},
+		{".ds-metrics-system.cpu-default-2023.01.31-000012", "metrics-system.cpu-default", true},
+		{".ds-my-data-stream-000003", "my-data-stream", true},
+		{"logs-000001", "", false},
+		{"twitter", "", false},
+		{".ds-", "", false},
+	}
+	for i, tt := range tests {
+		var hit SearchHit
+		body := fmt.Sprintf(`{"_index":%q,"_id":"1","_score":1.0}`, tt.Index)
+		if err := json.Unmarshal([]byte(body), &hit); err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		name, found := hit.DataStream()
+		if want, have := tt.Found, found; want != have {
+			t.Errorf("#%d: want found=%v, have %v", i, want, have)
+		}
+		if want, have := tt.Name, name; want != have {
+			t.Errorf("#%d: want %q, have %q", i, want, have)
+		}
+	}
+}
+
+func TestSearchHitWithStoredFieldsAndFields(t *testing.T) {
+	// Stored fields and fields from the fields API are both returned in the
+	// fields section of a hit
+	body := `{
+		"_index": "tweets",
+		"_id": "1",
+		"_score": 1.0,
+		"fields": {
+			"user": ["olivere"],
+			"retweets": [108],
+			"created": ["2014-01-18T23:59:58.000Z"],
+			"message.keyword": ["Welcome to Golang and Elasticsearch."]
+		}
+	}`
+	var hit SearchHit
+	if err := json.Unmarshal([]byte(body), &hit); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 4, len(hit.Fields); want != have {
+		t.Fatalf("expected %d fields, got %d: %v", want, have, hit.Fields)
+	}
+	users, found := hit.Fields.Strings("user")
+	if !found || len(users) != 1 || users[0] != "olivere" {
+		t.Errorf("expected stored field user=[olivere], got %v", users)
+	}
+	retweets, found := hit.Fields.Float64s("retweets")
+	if !found || len(retweets) != 1 || retweets[0] != 108 {
+		t.Errorf("expected stored field retweets=[108], got %v", retweets)
+	}
+	created, found := hit.Fields.Strings("created")
+	if !found || len(created) != 1 || created[0] != "2014-01-18T23:59:58.000Z" {
+		t.Errorf("expected field created, got %v", created)
+	}
+	messages, found := hit.Fields.Strings("message.keyword")
+	if !found || len(messages) != 1 {
+		t.Errorf("expected field message.keyword, got %v", messages)
+	}
+}
+
+func TestSearchHitWithFormattedFields(t *testing.T) {
+	body := `{
+		"hits": {
+			"total": {"value": 1, "relation": "eq"},
+			"hits": [{
+				"_index": "logs",
+				"_id": "1",
+				"fields": {
+					"@timestamp": ["2021-03-04"],
+					"user.id": ["kimchy"]
+				}
+			}]
+		}
+	}`
+	var res SearchResult
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 1, len(res.Hits.Hits); want != have {
+		t.Fatalf("expected %d hits; got: %d", want, have)
+	}
+	fields := res.Hits.Hits[0].Fields
+	ts, ok := fields.Strings("@timestamp")
+	if !ok {
+		t.Fatal("expected field @timestamp")
+	}
+	if want, have := []string{"2021-03-04"}, ts; !reflect.DeepEqual(want, have) {
+		t.Errorf("expected @timestamp %v; got: %v", want, have)
+	}
+	users, ok := fields.Strings("user.id")
+	if !ok {
+		t.Fatal("expected field user.id")
+	}
+	if want, have := []string{"kimchy"}, users; !reflect.DeepEqual(want, have) {
+		t.Errorf("expected user.id %v; got: %v", want, have)
+	}
+}
+
+func TestSearchResultWithFilterPath(t *testing.T) {
+	// Response to a search with filter_path=hits.hits._id
+	body := `{"hits":{"hits":[{"_id":"1"},{"_id":"2"}]}}`
+	var res SearchResult
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	if res.Shards != nil {
+		t.Errorf("expected no shards info; got: %+v", res.Shards)
+	}
+	if want, have := int64(0), res.TotalHits(); want != have {
+		t.Errorf("expected TotalHits=%d; got: %d", want, have)
+	}
+	if res.DidTimeout() {
+		t.Error("expected DidTimeout=false")
+	}
+	if err := res.Err(); err != nil {
+		t.Errorf("expected no error; got: %v", err)
+	}
+	if _, found := res.Aggregations.Terms("missing"); found {
+		t.Error("expected no aggregations")
+	}
+	if want, have := 2, len(res.Hits.Hits); want != have {
+		t.Fatalf("expected %d hits; got: %d", want, have)
+	}
+	for i, hit := range res.Hits.Hits {
+		if want, have := fmt.Sprint(i+1), hit.Id; want != have {
+			t.Errorf("#%d: expected Id=%q; got: %q", i, want, have)
+		}
+		if hit.Score != nil {
+			t.Errorf("#%d: expected no score; got: %v", i, *hit.Score)
+		}
+	}
+	if want, have := 2, len(res.Each(reflect.TypeOf(map[string]interface{}{}))); want != have {
+		t.Errorf("expected Each to return %d items; got: %d", want, have)
+	}
+}
+
+func TestSearchServiceDecodeResponse(t *testing.T) {
+	body := `{"took":3,"timed_out":false,"_shards":{"total":1,"successful":1,"skipped":0,"failed":0},"hits":{"total":{"value":1,"relation":"eq"},"hits":[{"_index":"tweets","_id":"1"}]}}`
+	req, _ := http.NewRequest("POST", "http://127.0.0.1:9200/_search", nil)
+	res := &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"X-Elastic-Product": []string{"Elasticsearch"}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+	result, err := NewSearchService().decodeResponse(req, res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := http.StatusOK, result.Status; want != have {
+		t.Errorf("want status %d, have %d", want, have)
+	}
+	if want, have := "Elasticsearch", result.Header.Get("X-Elastic-Product"); want != have {
+		t.Errorf("want header %q, have %q", want, have)
+	}
+	if want, have := int64(1), result.TotalHits(); want != have {
+		t.Errorf("want total hits %d, have %d", want, have)
+	}
+}
+
+func TestSearchServiceDecodeResponseError(t *testing.T) {
+	body := `{"error":{"type":"index_not_found_exception","reason":"no such index [tweets]"},"status":404}`
+	req, _ := http.NewRequest("POST", "http://127.0.0.1:9200/tweets/_search", nil)
+	res := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+	}
+	_, err := NewSearchService().decodeResponse(req, res)
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	e, ok := err.(*Error)
+	if !ok {
+		t.Fatalf("expected *Error, have %T", err)
+	}
+	if want, have := http.StatusNotFound, e.Status; want != have {
+		t.Errorf("want status %d, have %d", want, have)
+	}
+}
+
+func TestSearchServiceDecodeResponseProductCheck(t *testing.T) {
+	body := `{"took":3,"timed_out":false,"hits":{"total":{"value":0,"relation":"eq"},"hits":[]}}`
+	tests := []struct {
+		ProductCheck bool
+		Header       http.Header
+		Want         error
+	}{
+		{false, http.Header{}, nil},
+		{false, http.Header{"X-Elastic-Product": []string{"Elasticsearch"}}, nil},
+		{true, http.Header{}, ErrUnsupportedProduct},
+		{true, http.Header{"X-Elastic-Product": []string{"Elasticsearch"}}, nil},
+	}
+	for i, tt := range tests {
+		req, _ := http.NewRequest("POST", "http://127.0.0.1:9200/_search", nil)
+		res := &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     tt.Header,
+			Body:       ioutil.NopCloser(strings.NewReader(body)),
+		}
+		_, err := NewSearchService().ProductCheck(tt.ProductCheck).decodeResponse(req, res)
+		if want, have := tt.Want, err; want != have {
+			t.Errorf("#%d: want %v, have %v", i, want, have)
+		}
+	}
+}
+
+func TestSearchServiceRuntimeMappingsWithFields(t *testing.T) {
+	s := NewSearchService().
+		Query(NewMatchAllQuery()).
+		RuntimeMappings(RuntimeMappings{
+			"day_of_week": map[string]interface{}{
+				"type": "keyword",
+				"script": map[string]interface{}{
+					"source": "emit(doc['@timestamp'].value.dayOfWeekEnum.getDisplayName(TextStyle.FULL, Locale.ROOT))",
+				},
+			},
+		}).
+		Field("day_of_week", "")
+	src, err := s.body()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"fields":["day_of_week"],"query":{"match_all":{}},"runtime_mappings":{"day_of_week":{"script":{"source":"emit(doc['@timestamp'].value.dayOfWeekEnum.getDisplayName(TextStyle.FULL, Locale.ROOT))"},"type":"keyword"}}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchResultNextPointInTime(t *testing.T) {
+	body := `{"pit_id":"46ToAwMDaWR5BXV1aWQyKwZub2RlXzMAAAAAAAAAACoBYwADaWR4BXV1aWQxAgZub2RlXzEAAAAAAAAAAAEBYQADaWR5BXV1aWQyKgZub2RlXzIAAAAAAAAAAAwBYgACBXV1aWQyAAAFdXVpZDEAAQltYXRjaF9hbGw_gAAAAA==","hits":{"total":{"value":0,"relation":"eq"},"hits":[]}}`
+	var res SearchResult
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	pit := res.NextPointInTime("1m")
+	if pit == nil {
+		t.Fatal("expected PointInTime")
+	}
+	src, err := pit.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"id":"46ToAwMDaWR5BXV1aWQyKwZub2RlXzMAAAAAAAAAACoBYwADaWR4BXV1aWQxAgZub2RlXzEAAAAAAAAAAAEBYQADaWR5BXV1aWQyKgZub2RlXzIAAAAAAAAAAAwBYgACBXV1aWQyAAAFdXVpZDEAAQltYXRjaF9hbGw_gAAAAA==","keep_alive":"1m"}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+
+	if pit := new(SearchResult).NextPointInTime("1m"); pit != nil {
+		t.Errorf("expected no PointInTime without PitId; got: %+v", pit)
+	}
+}
+
+func TestSearchServiceIndex(t *testing.T) {
+	path, _, err := NewSearchService().Index("tweets", "logs-*").buildURL()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := "/tweets,logs-%2A/_search", path; want != have {
+		t.Fatalf("expected path %q; got: %q", want, have)
+	}
+}
+
+func TestSearchServicePointInTimeWithIndex(t *testing.T) {
+	pit := NewPointInTimeWithKeepAlive("46ToAwMDaWR5BXV1aWQy", "1m")
+	if err := NewSearchService().PointInTime(pit).Validate(); err != nil {
+		t.Fatalf("expected no validation error; got: %v", err)
+	}
+	if err := NewSearchService().Index("tweets").Validate(); err != nil {
+		t.Fatalf("expected no validation error; got: %v", err)
+	}
+	err := NewSearchService().Index("tweets").PointInTime(pit).Validate()
+	if err == nil {
+		t.Fatal("expected validation error")
+	}
+	if want, have := "elastic: indices must not be set when searching with a point in time", err.Error(); want != have {
+		t.Fatalf("expected error %q; got: %q", want, have)
+	}
+
+	// The rule is applied when building the request
+	_, err = NewSearchService().Index("tweets").PointInTime(pit).buildRequest("http://127.0.0.1:9200")
+	if err == nil {
+		t.Fatal("expected buildRequest to reject indices with a point in time")
+	}
+	if want, have := "elastic: indices must not be set when searching with a point in time", err.Error(); want != have {
+		t.Fatalf("expected error %q; got: %q", want, have)
+	}
+	req, err := NewSearchService().PointInTime(pit).buildRequest("http://127.0.0.1:9200")
+	if err != nil {
+		t.Fatalf("expected no error; got: %v", err)
+	}
+	if want, have := "/_search", req.URL.Path; want != have {
+		t.Fatalf("expected path %q; got: %q", want, have)
+	}
+}
+
+func TestSearchServiceValidateRescoreWindow(t *testing.T) {
+	rescore := func() *Rescore {
+		return NewRescore().Rescorer(NewQueryRescorer(NewMatchPhraseQuery("message", "the quick brown")))
+	}
+	tests := []struct {
+		Service *SearchService
+		Error   string
+	}{
+		{
+			NewSearchService().Rescorer(rescore()),
+			"",
+		},
+		{
+			NewSearchService().Size(20).Rescorer(rescore().WindowSize(50)),
+			"",
+		},
+		{
+			NewSearchService().From(40).Size(20).Rescorer(rescore().WindowSize(50)),
+			"elastic: window size 50 of rescore #0 is smaller than from+size (60)",
+		},
+		{
+			NewSearchService().Size(20).Rescorer(rescore()),
+			"",
+		},
+		{
+			NewSearchService().Size(20).DefaultRescoreWindowSize(20).Rescorer(rescore()),
+			"",
+		},
+		{
+			NewSearchService().Size(20).DefaultRescoreWindowSize(15).Rescorer(rescore()),
+			"elastic: window size 15 of rescore #0 is smaller than from+size (20)",
+		},
+		{
+			NewSearchService().Size(20).Rescorer(rescore().WindowSize(100)).Rescorer(rescore().WindowSize(5)),
+			"elastic: window size 5 of rescore #1 is smaller than from+size (20)",
+		},
+		{
+			NewSearchService().Size(20).Rescorer(rescore().WindowSize(5)).ValidateRescoreWindow(false),
+			"",
+		},
+	}
+	for i, tt := range tests {
+		err := tt.Service.Validate()
+		if tt.Error == "" {
+			if err != nil {
+				t.Errorf("#%d: expected no error; got: %v", i, err)
+			}
+			continue
+		}
+		if err == nil {
+			t.Errorf("#%d: expected error %q", i, tt.Error)
+			continue
+		}
+		if want, have := tt.Error, err.Error(); want != have {
+			t.Errorf("#%d: expected error %q; got: %q", i, want, have)
+		}
+	}
+}
+
+func TestSearchServiceBuildRequestRescoreWindow(t *testing.T) {
+	rescore := NewRescore().
+		Rescorer(NewQueryRescorer(NewMatchPhraseQuery("message", "the quick brown"))).
+		WindowSize(5)
+	if _, err := NewSearchService().Size(20).Rescorer(rescore).buildRequest("http://127.0.0.1:9200"); err == nil {
+		t.Fatal("expected buildRequest to reject a rescore window smaller than from+size")
+	}
+	if _, err := NewSearchService().Size(20).Rescorer(rescore).ValidateRescoreWindow(false).buildRequest("http://127.0.0.1:9200"); err != nil {
+		t.Fatalf("expected no error with the check disabled; got: %v", err)
+	}
+}
+
+func TestSearchServiceKNN(t *testing.T) {
+	s := NewSearchService().
+		KNN(NewKnnQuery("image-vector", []float32{-5, 9, -12}, 10, 100).
+			Filter(NewTermQuery("file-type", "png")))
+	src, err := s.body()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"knn":{"field":"image-vector","filter":{"term":{"file-type":"png"}},"k":10,"num_candidates":100,"query_vector":[-5,9,-12]}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchServiceMultipleKNN(t *testing.T) {
+	s := NewSearchService().
+		KNN(NewKnnQuery("image-vector", []float32{54, 10, -2}, 5, 50).Boost(0.1)).
+		KNN(NewKnnQuery("title-vector", []float32{1, 20, -52, 23, 10}, 10, 10).Boost(0.5))
+	src, err := s.body()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"knn":[{"boost":0.1,"field":"image-vector","k":5,"num_candidates":50,"query_vector":[54,10,-2]},{"boost":0.5,"field":"title-vector","k":10,"num_candidates":10,"query_vector":[1,20,-52,23,10]}]}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestSearchServiceKNNSkipsNil(t *testing.T) {
+	s := NewSearchService().
+		KNN(nil).
+		KNN(nil, NewKnnQuery("image-vector", []float32{54, 10, -2}, 5, 50), nil)
+	src, err := s.body()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"knn":{"field":"image-vector","k":5,"num_candidates":50,"query_vector":[54,10,-2]}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+
+	src, err = NewSearchSource().KNN(nil).Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err = json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	if want, have := `{}`, string(data); want != have {
+		t.Errorf("expected\n%s\n,got:\n%s", want, have)
+	}
+}
+
+func TestSearchServiceEncoder(t *testing.T) {
+	enc := &recordingEncoder{}
+	s := NewSearchService().Encoder(enc).Query(NewMatchAllQuery())
+	req, err := s.buildRequest("http://127.0.0.1:9200")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 1, enc.calls; want != have {
+		t.Fatalf("want %d calls to encoder, have %d", want, have)
+	}
+	data, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, have := `{"query":{"match_all":{}}}`, string(data); want != have {
+		t.Errorf("expected\n%s\n,got:\n%s", want, have)
+	}
+}
+
+func TestSearchServiceSourceIncludesExcludes(t *testing.T) {
+	tests := []struct {
+		Service  *SearchService
+		Expected string
+	}{
+		{
+			NewSearchService().SourceIncludes("user", "message"),
+			`{"_source":{"includes":["user","message"]}}`,
+		},
+		{
+			NewSearchService().SourceExcludes("retweets"),
+			`{"_source":{"excludes":["retweets"]}}`,
+		},
+		{
+			NewSearchService().SourceIncludes("obj1.*", "obj2.*").SourceExcludes("*.description"),
+			`{"_source":{"excludes":["*.description"],"includes":["obj1.*","obj2.*"]}}`,
+		},
+	}
+
+	for i, tt := range tests {
+		src, err := tt.Service.body()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		data, err := json.Marshal(src)
+		if err != nil {
+			t.Fatalf("#%d: marshaling to JSON failed: %v", i, err)
+		}
+		if got := string(data); got != tt.Expected {
+			t.Errorf("#%d: expected\n%s\n,got:\n%s", i, tt.Expected, got)
+		}
+	}
+}
@@ -50,6 +50,28 @@ func TestSortInfoComplex(t *testing.T) {
 	}
 }
 
+func TestSortInfoWithNested(t *testing.T) {
+	builder := SortInfo{
+		Field:     "offers.price",
+		Ascending: false,
+		SortMode:  "max",
+		Nested:    NewNestedSort("offers").Filter(NewTermQuery("offers.color", "blue")),
+	}
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"offers.price":{"mode":"max","nested":{"filter":{"term":{"offers.color":"blue"}},"path":"offers"},"order":"desc"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestScoreSort(t *testing.T) {
 	builder := NewScoreSort()
 	if builder.ascending != false {
@@ -204,6 +226,29 @@ func TestGeoDistanceSortOrderDesc(t *testing.T) {
 		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
 	}
 }
+
+func TestGeoDistanceSortWithMultiplePoints(t *testing.T) {
+	builder := NewGeoDistanceSort("pin.location").
+		Points(GeoPointFromLatLon(40, -70), GeoPointFromLatLon(42, -71)).
+		Unit("km").
+		DistanceType("arc").
+		SortMode("min").
+		Asc()
+	src, err := builder.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"_geo_distance":{"distance_type":"arc","mode":"min","order":"asc","pin.location":[{"lat":40,"lon":-70},{"lat":42,"lon":-71}],"unit":"km"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
 func TestScriptSort(t *testing.T) {
 	builder := NewScriptSort(NewScript("doc['field_name'].value * factor").Param("factor", 1.1), "number").Order(true)
 	src, err := builder.Source()
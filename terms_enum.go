@@ -0,0 +1,205 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// TermsEnumService returns terms of a field that match a prefix, e.g.
+// for auto-complete. It is available as of Elasticsearch 7.14.
+// See https://www.elastic.co/guide/en/elasticsearch/reference/7.x/search-terms-enum.html.
+type TermsEnumService struct {
+	pretty     *bool       // pretty format the returned JSON response
+	human      *bool       // return human readable values for statistics
+	errorTrace *bool       // include the stack trace of returned errors
+	filterPath []string    // list of filters used to reduce the response
+	headers    http.Header // custom request-level HTTP headers
+
+	index           []string
+	field           string
+	str             *string
+	size            *int
+	caseInsensitive *bool
+	searchAfter     string
+	indexFilter     Query
+	timeout         string
+}
+
+// NewTermsEnumService creates a new TermsEnumService.
+func NewTermsEnumService(indices ...string) *TermsEnumService {
+	return &TermsEnumService{
+		index: indices,
+	}
+}
+
+// Pretty tells Elasticsearch whether to return a formatted JSON response.
+func (s *TermsEnumService) Pretty(pretty bool) *TermsEnumService {
+	s.pretty = &pretty
+	return s
+}
+
+// Human specifies whether human readable values should be returned in
+// the JSON response, e.g. "7.5mb".
+func (s *TermsEnumService) Human(human bool) *TermsEnumService {
+	s.human = &human
+	return s
+}
+
+// ErrorTrace specifies whether to include the stack trace of returned errors.
+func (s *TermsEnumService) ErrorTrace(errorTrace bool) *TermsEnumService {
+	s.errorTrace = &errorTrace
+	return s
+}
+
+// FilterPath specifies a list of filters used to reduce the response.
+func (s *TermsEnumService) FilterPath(filterPath ...string) *TermsEnumService {
+	s.filterPath = filterPath
+	return s
+}
+
+// Header adds a header to the request.
+func (s *TermsEnumService) Header(name string, value string) *TermsEnumService {
+	if s.headers == nil {
+		s.headers = http.Header{}
+	}
+	s.headers.Add(name, value)
+	return s
+}
+
+// Headers specifies the headers of the request.
+func (s *TermsEnumService) Headers(headers http.Header) *TermsEnumService {
+	s.headers = headers
+	return s
+}
+
+// Index sets the names of the indices to search for terms.
+func (s *TermsEnumService) Index(index ...string) *TermsEnumService {
+	s.index = append(s.index, index...)
+	return s
+}
+
+// Field is the field to return terms of.
+func (s *TermsEnumService) Field(field string) *TermsEnumService {
+	s.field = field
+	return s
+}
+
+// String is the prefix the returned terms must start with.
+func (s *TermsEnumService) String(str string) *TermsEnumService {
+	s.str = &str
+	return s
+}
+
+// Size is the maximum number of terms to return. It defaults to 10.
+func (s *TermsEnumService) Size(size int) *TermsEnumService {
+	s.size = &size
+	return s
+}
+
+// CaseInsensitive matches the prefix regardless of case if true.
+func (s *TermsEnumService) CaseInsensitive(caseInsensitive bool) *TermsEnumService {
+	s.caseInsensitive = &caseInsensitive
+	return s
+}
+
+// SearchAfter returns terms after the given one, e.g. the last term of
+// the previous page.
+func (s *TermsEnumService) SearchAfter(searchAfter string) *TermsEnumService {
+	s.searchAfter = searchAfter
+	return s
+}
+
+// IndexFilter restricts the indices the terms are taken from, e.g. by a
+// range query on @timestamp.
+func (s *TermsEnumService) IndexFilter(indexFilter Query) *TermsEnumService {
+	s.indexFilter = indexFilter
+	return s
+}
+
+// Timeout is the maximum time to spend collecting terms, e.g. "1s".
+func (s *TermsEnumService) Timeout(timeout string) *TermsEnumService {
+	s.timeout = timeout
+	return s
+}
+
+// buildURL builds the URL for the operation.
+func (s *TermsEnumService) buildURL() (string, url.Values, error) {
+	escaped := make([]string, len(s.index))
+	for i, index := range s.index {
+		escaped[i] = url.PathEscape(index)
+	}
+	path := "/" + strings.Join(escaped, ",") + "/_terms_enum"
+
+	// Add query string parameters
+	params := url.Values{}
+	if v := s.pretty; v != nil {
+		params.Set("pretty", fmt.Sprint(*v))
+	}
+	if v := s.human; v != nil {
+		params.Set("human", fmt.Sprint(*v))
+	}
+	if v := s.errorTrace; v != nil {
+		params.Set("error_trace", fmt.Sprint(*v))
+	}
+	if len(s.filterPath) > 0 {
+		params.Set("filter_path", strings.Join(s.filterPath, ","))
+	}
+	return path, params, nil
+}
+
+// Validate checks if the operation is valid.
+func (s *TermsEnumService) Validate() error {
+	var invalid []string
+	if len(s.index) < 1 {
+		invalid = append(invalid, "Index")
+	}
+	if s.field == "" {
+		invalid = append(invalid, "Field")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// Source returns the request body.
+func (s *TermsEnumService) Source() (interface{}, error) {
+	source := make(map[string]interface{})
+	source["field"] = s.field
+	if v := s.str; v != nil {
+		source["string"] = *v
+	}
+	if v := s.size; v != nil {
+		source["size"] = *v
+	}
+	if v := s.caseInsensitive; v != nil {
+		source["case_insensitive"] = *v
+	}
+	if s.searchAfter != "" {
+		source["search_after"] = s.searchAfter
+	}
+	if s.indexFilter != nil {
+		src, err := s.indexFilter.Source()
+		if err != nil {
+			return nil, err
+		}
+		source["index_filter"] = src
+	}
+	if s.timeout != "" {
+		source["timeout"] = s.timeout
+	}
+	return source, nil
+}
+
+// TermsEnumResponse is the response of TermsEnumService.
+type TermsEnumResponse struct {
+	Shards   *ShardsInfo `json:"_shards,omitempty"`
+	Terms    []string    `json:"terms"`
+	Complete bool        `json:"complete"` // false if not all shards or indices returned results in time
+}
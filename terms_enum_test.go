@@ -0,0 +1,83 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTermsEnumBuildURL(t *testing.T) {
+	tests := []struct {
+		Service *TermsEnumService
+		Path    string
+		Params  string
+	}{
+		{NewTermsEnumService("stackoverflow"), "/stackoverflow/_terms_enum", ""},
+		{NewTermsEnumService("logs-*", "metrics").Pretty(true), "/logs-%2A,metrics/_terms_enum", "pretty=true"},
+	}
+	for i, tt := range tests {
+		path, params, err := tt.Service.buildURL()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if want, have := tt.Path, path; want != have {
+			t.Errorf("#%d: want path %q, have %q", i, want, have)
+		}
+		if want, have := tt.Params, params.Encode(); want != have {
+			t.Errorf("#%d: want params %q, have %q", i, want, have)
+		}
+	}
+}
+
+func TestTermsEnumValidate(t *testing.T) {
+	err := NewTermsEnumService().Validate()
+	if err == nil {
+		t.Fatal("expected error")
+	}
+	if want, have := "missing required fields: [Index Field]", err.Error(); want != have {
+		t.Errorf("want %q, have %q", want, have)
+	}
+}
+
+func TestTermsEnumSource(t *testing.T) {
+	s := NewTermsEnumService("stackoverflow").
+		Field("tags").
+		String("kiba").
+		Size(5).
+		CaseInsensitive(true).
+		SearchAfter("kibana").
+		IndexFilter(NewRangeQuery("@timestamp").Gte("2021-01-01"))
+	src, err := s.Source()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(src)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"case_insensitive":true,"field":"tags","index_filter":{"range":{"@timestamp":{"from":"2021-01-01","include_lower":true,"include_upper":true,"to":null}}},"search_after":"kibana","size":5,"string":"kiba"}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestTermsEnumResponse(t *testing.T) {
+	body := `{"_shards":{"total":1,"successful":1,"failed":0},"terms":["kibana","kibana-dashboards"],"complete":true}`
+	var res TermsEnumResponse
+	if err := json.Unmarshal([]byte(body), &res); err != nil {
+		t.Fatal(err)
+	}
+	if want, have := 2, len(res.Terms); want != have {
+		t.Fatalf("want %d terms, have %d", want, have)
+	}
+	if want, have := "kibana-dashboards", res.Terms[1]; want != have {
+		t.Errorf("want term %q, have %q", want, have)
+	}
+	if !res.Complete {
+		t.Error("want complete")
+	}
+}
@@ -5,7 +5,10 @@
 package elastic
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 )
 
 // TermvectorsService returns information and statistics on terms in the
@@ -44,6 +47,11 @@ type TermvectorsService struct {
 	bodyString       string
 }
 
+// NewTermvectorsService creates a new TermvectorsService.
+func NewTermvectorsService() *TermvectorsService {
+	return &TermvectorsService{}
+}
+
 // Pretty tells Elasticsearch whether to return a formatted JSON response.
 func (s *TermvectorsService) Pretty(pretty bool) *TermvectorsService {
 	s.pretty = &pretty
@@ -221,6 +229,130 @@ func (s *TermvectorsService) BodyString(body string) *TermvectorsService {
 	return s
 }
 
+// buildURL builds the URL for the operation. Term vectors of a stored
+// document are retrieved via GET by its id, while an artificial document
+// (see Doc) is sent via POST in the request body.
+func (s *TermvectorsService) buildURL() (string, string, url.Values, error) {
+	method := "GET"
+	if s.doc != nil || s.bodyJson != nil || s.bodyString != "" {
+		method = "POST"
+	}
+
+	path := "/" + url.PathEscape(s.index)
+	if s.typ != "" {
+		path += "/" + url.PathEscape(s.typ)
+		if s.id != "" {
+			path += "/" + url.PathEscape(s.id)
+		}
+		path += "/_termvectors"
+	} else {
+		path += "/_termvectors"
+		if s.id != "" {
+			path += "/" + url.PathEscape(s.id)
+		}
+	}
+
+	// Add query string parameters
+	params := url.Values{}
+	if v := s.pretty; v != nil {
+		params.Set("pretty", fmt.Sprint(*v))
+	}
+	if v := s.human; v != nil {
+		params.Set("human", fmt.Sprint(*v))
+	}
+	if v := s.errorTrace; v != nil {
+		params.Set("error_trace", fmt.Sprint(*v))
+	}
+	if len(s.filterPath) > 0 {
+		params.Set("filter_path", strings.Join(s.filterPath, ","))
+	}
+	if s.dfs != nil {
+		params.Set("dfs", fmt.Sprint(*s.dfs))
+	}
+	if s.fieldStatistics != nil {
+		params.Set("field_statistics", fmt.Sprint(*s.fieldStatistics))
+	}
+	if len(s.fields) > 0 {
+		params.Set("fields", strings.Join(s.fields, ","))
+	}
+	if s.offsets != nil {
+		params.Set("offsets", fmt.Sprint(*s.offsets))
+	}
+	if s.parent != "" {
+		params.Set("parent", s.parent)
+	}
+	if s.payloads != nil {
+		params.Set("payloads", fmt.Sprint(*s.payloads))
+	}
+	if s.positions != nil {
+		params.Set("positions", fmt.Sprint(*s.positions))
+	}
+	if s.preference != "" {
+		params.Set("preference", s.preference)
+	}
+	if s.realtime != nil {
+		params.Set("realtime", fmt.Sprint(*s.realtime))
+	}
+	if s.routing != "" {
+		params.Set("routing", s.routing)
+	}
+	if s.termStatistics != nil {
+		params.Set("term_statistics", fmt.Sprint(*s.termStatistics))
+	}
+	if s.version != nil {
+		params.Set("version", fmt.Sprint(s.version))
+	}
+	if s.versionType != "" {
+		params.Set("version_type", s.versionType)
+	}
+	return method, path, params, nil
+}
+
+// Validate checks if the operation is valid. It requires an index and
+// either the id of a stored document or an artificial document, passed
+// via Doc or as part of the body.
+func (s *TermvectorsService) Validate() error {
+	var invalid []string
+	if s.index == "" {
+		invalid = append(invalid, "Index")
+	}
+	if s.id == "" && s.doc == nil && s.bodyJson == nil && s.bodyString == "" {
+		invalid = append(invalid, "Id or Doc")
+	}
+	if len(invalid) > 0 {
+		return fmt.Errorf("missing required fields: %v", invalid)
+	}
+	return nil
+}
+
+// body returns the request body, or nil if the request has no body.
+func (s *TermvectorsService) body() (interface{}, error) {
+	if s.bodyJson != nil {
+		return s.bodyJson, nil
+	}
+	if s.bodyString != "" {
+		return s.bodyString, nil
+	}
+	data := make(map[string]interface{})
+	if s.doc != nil {
+		data["doc"] = s.doc
+	}
+	if len(s.perFieldAnalyzer) > 0 {
+		data["per_field_analyzer"] = s.perFieldAnalyzer
+	}
+	if s.filter != nil {
+		src, err := s.filter.Source()
+		if err != nil {
+			return nil, err
+		}
+		data["filter"] = src
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	return data, nil
+}
+
 // -- Filter settings --
 
 // TermvectorsFilterSettings adds additional filters to a Termsvector request.
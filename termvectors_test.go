@@ -0,0 +1,155 @@
+// Copyright 2012-present Oliver Eilhard. All rights reserved.
+// Use of this source code is governed by a MIT-license.
+// See http://olivere.mit-license.org/license.txt for details.
+
+package elastic
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestTermVectorsValidate(t *testing.T) {
+	tests := []struct {
+		Service               *TermvectorsService
+		ExpectValidateFailure bool
+	}{
+		// #0: Missing index
+		{NewTermvectorsService().Id("1"), true},
+		// #1: Missing id and doc
+		{NewTermvectorsService().Index("twitter"), true},
+		// #2: Stored document
+		{NewTermvectorsService().Index("twitter").Id("1"), false},
+		// #3: Artificial document
+		{NewTermvectorsService().Index("twitter").Doc(map[string]interface{}{"message": "hello"}), false},
+	}
+
+	for i, tt := range tests {
+		err := tt.Service.Validate()
+		if err != nil && !tt.ExpectValidateFailure {
+			t.Errorf("#%d: expected no error, got: %v", i, err)
+		}
+		if err == nil && tt.ExpectValidateFailure {
+			t.Errorf("#%d: expected error, got: nil", i)
+		}
+	}
+}
+
+func TestTermVectorsBuildURL(t *testing.T) {
+	tests := []struct {
+		Service        *TermvectorsService
+		ExpectedMethod string
+		ExpectedPath   string
+		ExpectedParams string
+	}{
+		{
+			NewTermvectorsService().Index("twitter").Id("1"),
+			"GET",
+			"/twitter/_termvectors/1",
+			"",
+		},
+		{
+			NewTermvectorsService().Index("twitter").Id("1").Fields("message", "tags").TermStatistics(true),
+			"GET",
+			"/twitter/_termvectors/1",
+			"fields=message%2Ctags&term_statistics=true",
+		},
+		{
+			NewTermvectorsService().Index("twitter").Type("doc").Id("1"),
+			"GET",
+			"/twitter/doc/1/_termvectors",
+			"",
+		},
+		{
+			NewTermvectorsService().Index("twitter").Doc(map[string]interface{}{"message": "hello"}),
+			"POST",
+			"/twitter/_termvectors",
+			"",
+		},
+	}
+
+	for i, tt := range tests {
+		method, path, params, err := tt.Service.buildURL()
+		if err != nil {
+			t.Fatalf("#%d: %v", i, err)
+		}
+		if want, have := tt.ExpectedMethod, method; want != have {
+			t.Errorf("#%d: expected method %q; got: %q", i, want, have)
+		}
+		if want, have := tt.ExpectedPath, path; want != have {
+			t.Errorf("#%d: expected path %q; got: %q", i, want, have)
+		}
+		if want, have := tt.ExpectedParams, params.Encode(); want != have {
+			t.Errorf("#%d: expected params %q; got: %q", i, want, have)
+		}
+	}
+}
+
+func TestTermVectorsBody(t *testing.T) {
+	// Stored document without additional settings has no body
+	body, err := NewTermvectorsService().Index("twitter").Id("1").body()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if body != nil {
+		t.Fatalf("expected no body; got: %v", body)
+	}
+
+	// Artificial document
+	body, err = NewTermvectorsService().
+		Index("twitter").
+		Doc(map[string]interface{}{"message": "hello world"}).
+		PerFieldAnalyzer(map[string]string{"message": "keyword"}).
+		Filter(NewTermvectorsFilterSettings().MaxNumTerms(3)).
+		body()
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(body)
+	if err != nil {
+		t.Fatalf("marshaling to JSON failed: %v", err)
+	}
+	got := string(data)
+	expected := `{"doc":{"message":"hello world"},"filter":{"max_num_terms":3},"per_field_analyzer":{"message":"keyword"}}`
+	if got != expected {
+		t.Errorf("expected\n%s\n,got:\n%s", expected, got)
+	}
+}
+
+func TestTermVectorsResponseDecode(t *testing.T) {
+	s := `{
+	"_index": "twitter",
+	"_id": "1",
+	"_version": 1,
+	"found": true,
+	"took": 6,
+	"term_vectors": {
+		"message": {
+			"field_statistics": { "sum_doc_freq": 4, "doc_count": 2, "sum_ttf": 6 },
+			"terms": {
+				"hello": {
+					"term_freq": 1,
+					"tokens": [ { "position": 0, "start_offset": 0, "end_offset": 5 } ]
+				}
+			}
+		}
+	}
+}`
+	var res TermvectorsResponse
+	if err := json.Unmarshal([]byte(s), &res); err != nil {
+		t.Fatal(err)
+	}
+	if !res.Found {
+		t.Fatalf("expected found = true; got: %v", res.Found)
+	}
+	field, ok := res.TermVectors["message"]
+	if !ok {
+		t.Fatal("expected term vectors for field message")
+	}
+	if want, have := int64(2), field.FieldStatistics.DocCount; want != have {
+		t.Fatalf("expected doc_count = %d; got: %d", want, have)
+	}
+	if want, have := int64(1), field.Terms["hello"].TermFreq; want != have {
+		t.Fatalf("expected term_freq = %d; got: %d", want, have)
+	}
+}